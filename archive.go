@@ -0,0 +1,206 @@
+package solc
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// maxArchiveEntrySize defines the maximum size of a single extracted archive entry.
+	// It protects against decompression bombs.
+	maxArchiveEntrySize = 512 * 1024 * 1024
+)
+
+// ArchiveType represents the type of archive a downloaded asset is packaged in.
+type ArchiveType string
+
+const (
+	// ArchiveNone denotes a bare, non-archived asset.
+	ArchiveNone ArchiveType = "none"
+
+	// ArchiveZip denotes a zip archive.
+	ArchiveZip ArchiveType = "zip"
+
+	// ArchiveTarGz denotes a gzip compressed tar archive.
+	ArchiveTarGz ArchiveType = "tar.gz"
+)
+
+// detectArchiveType inspects the first bytes of the provided file and returns the archive type it's packaged in.
+func detectArchiveType(file string) (ArchiveType, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return ArchiveNone, err
+	}
+	defer f.Close()
+
+	header := make([]byte, 4)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return ArchiveNone, err
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")):
+		return ArchiveZip, nil
+	case bytes.HasPrefix(header, []byte{0x1f, 0x8b}):
+		return ArchiveTarGz, nil
+	default:
+		return ArchiveNone, nil
+	}
+}
+
+// extractArchiveIfNeeded checks whether the downloaded file is an archive and, if it is, replaces it with the
+// solc executable found in the archive. Any DLLs shipped along the executable are extracted next to it so that
+// the Windows loader is able to locate them.
+func extractArchiveIfNeeded(file string) error {
+	archiveType, err := detectArchiveType(file)
+	if err != nil {
+		return err
+	}
+
+	var binary []byte
+	switch archiveType {
+	case ArchiveZip:
+		binary, err = extractZip(file)
+	case ArchiveTarGz:
+		binary, err = extractTarGz(file)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if binary == nil {
+		return fmt.Errorf("no solc executable found in archive %s", filepath.Base(file))
+	}
+
+	// The archive is closed at this point so it's safe to overwrite it, Windows included.
+	if err := os.WriteFile(file, binary, 0600); err != nil {
+		return fmt.Errorf("failed to write extracted binary: %w", err)
+	}
+
+	return nil
+}
+
+// extractZip extracts any DLLs from the zip archive located at file and returns the solc executable content.
+func extractZip(file string) ([]byte, error) {
+	reader, err := zip.OpenReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zip archive: %w", err)
+	}
+	defer reader.Close()
+
+	var binary []byte
+	for _, entry := range reader.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+
+		rc, err := entry.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open zip archive entry %s: %w", entry.Name, err)
+		}
+
+		content, err := readArchiveEntry(rc)
+		if closeErr := rc.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zip archive entry %s: %w", entry.Name, err)
+		}
+
+		if binary, err = handleArchiveEntry(file, entry.Name, content, binary); err != nil {
+			return nil, err
+		}
+	}
+
+	return binary, nil
+}
+
+// extractTarGz extracts any DLLs from the gzip compressed tar archive located at file and returns the solc
+// executable content.
+func extractTarGz(file string) ([]byte, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gzip archive: %w", err)
+	}
+	defer gz.Close()
+
+	var binary []byte
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		content, err := readArchiveEntry(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tar archive entry %s: %w", header.Name, err)
+		}
+
+		if binary, err = handleArchiveEntry(file, header.Name, content, binary); err != nil {
+			return nil, err
+		}
+	}
+
+	return binary, nil
+}
+
+// readArchiveEntry reads a single archive entry, refusing entries larger than maxArchiveEntrySize.
+func readArchiveEntry(r io.Reader) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxArchiveEntrySize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) > maxArchiveEntrySize {
+		return nil, fmt.Errorf("archive entry exceeds maximum size of %d bytes", maxArchiveEntrySize)
+	}
+
+	return content, nil
+}
+
+// handleArchiveEntry writes DLL entries next to the binary and returns the content of the solc executable
+// entry once found. Entry paths are reduced to their base name to prevent path traversal.
+func handleArchiveEntry(file string, name string, content []byte, binary []byte) ([]byte, error) {
+	base := path.Base(strings.ReplaceAll(name, "\\", "/"))
+	lower := strings.ToLower(base)
+
+	switch {
+	case strings.HasSuffix(lower, ".dll"):
+		dllPath := filepath.Join(filepath.Dir(file), base)
+		if err := os.WriteFile(dllPath, content, 0600); err != nil {
+			return nil, fmt.Errorf("failed to extract %s: %w", base, err)
+		}
+		return binary, nil
+	case lower == "solc.exe" || lower == "solc" || (binary == nil && strings.HasPrefix(lower, "solc")):
+		return content, nil
+	default:
+		return binary, nil
+	}
+}
@@ -0,0 +1,122 @@
+package solc
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractArchiveIfNeeded(t *testing.T) {
+	binary := []byte("MZ fake solc executable")
+	dll := []byte("fake dll")
+
+	zipped := func() []byte {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		for name, content := range map[string][]byte{
+			"solc-windows/solc.exe":   binary,
+			"solc-windows/z3.dll":     dll,
+			"solc-windows/README.txt": []byte("readme"),
+		} {
+			f, err := w.Create(name)
+			assert.NoError(t, err)
+			_, err = f.Write(content)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	tarred := func() []byte {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		w := tar.NewWriter(gz)
+		for name, content := range map[string][]byte{"solc.exe": binary, "../z3.dll": dll} {
+			assert.NoError(t, w.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+			_, err := w.Write(content)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, w.Close())
+		assert.NoError(t, gz.Close())
+		return buf.Bytes()
+	}
+
+	emptyZip := func() []byte {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		f, err := w.Create("README.txt")
+		assert.NoError(t, err)
+		_, err = f.Write([]byte("readme"))
+		assert.NoError(t, err)
+		assert.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name        string
+		content     []byte
+		archiveType ArchiveType
+		expected    []byte
+		expectDLL   bool
+		wantErr     bool
+	}{
+		{
+			name:        "Bare Binary",
+			content:     binary,
+			archiveType: ArchiveNone,
+			expected:    binary,
+		},
+		{
+			name:        "Zip Archive",
+			content:     zipped(),
+			archiveType: ArchiveZip,
+			expected:    binary,
+			expectDLL:   true,
+		},
+		{
+			name:        "Tar Gz Archive",
+			content:     tarred(),
+			archiveType: ArchiveTarGz,
+			expected:    binary,
+			expectDLL:   true,
+		},
+		{
+			name:        "Zip Archive Without Executable",
+			content:     emptyZip(),
+			archiveType: ArchiveZip,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			file := filepath.Join(tempDir, "solc-0.8.20.exe")
+			assert.NoError(t, os.WriteFile(file, tt.content, 0600))
+
+			archiveType, err := detectArchiveType(file)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.archiveType, archiveType)
+
+			err = extractArchiveIfNeeded(file)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			content, err := os.ReadFile(file)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, content)
+
+			_, err = os.Stat(filepath.Join(tempDir, "z3.dll"))
+			assert.Equal(t, tt.expectDLL, err == nil)
+		})
+	}
+}
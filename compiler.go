@@ -4,40 +4,72 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"regexp"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	"go.uber.org/zap"
 )
 
+// ContractSizeLimit is the maximum size in bytes of deployed contract bytecode, as defined by EIP-170.
+const ContractSizeLimit = 24576
+
+// CompilationMode represents the solc pipeline a compilation result was produced by.
+type CompilationMode string
+
+const (
+	// ModeCombinedJSON denotes the simple mode, parsing the --combined-json output. The bytecode and the ABI are
+	// populated, along with the source maps, the opcodes and the legacy assembly when requested through
+	// CompilerConfig.AddCombinedJsonOutputs.
+	ModeCombinedJSON CompilationMode = "combined-json"
+
+	// ModeStandardJSON denotes the standard-json mode, which also populates the deployed bytecode, the opcodes,
+	// the metadata, the immutable references and the source maps, depending on the requested output selection.
+	ModeStandardJSON CompilationMode = "standard-json"
+)
+
 // Compiler represents a Solidity compiler instance.
 type Compiler struct {
 	ctx    context.Context // The context for the compiler.
 	source string          // The Solidity sources to compile.
 	solc   *Solc           // The solc instance.
 	config *CompilerConfig // The configuration for the compiler.
+	reader io.Reader       // The reader streaming the Solidity sources, used instead of the source when set.
+
+	readerConsumed bool // Whether the reader was consumed by a compilation.
 }
 
 // NewCompiler creates a new Compiler instance with the given context, configuration, and source.
 // It returns an error if the provided configuration, solc instance, or source is invalid.
 func NewCompiler(ctx context.Context, solc *Solc, config *CompilerConfig, source string) (*Compiler, error) {
-	if config == nil {
-		return nil, fmt.Errorf("config must be provided to create new compiler")
+	if source == "" {
+		return nil, fmt.Errorf("source code must be provided to create new compiler")
 	}
 
-	if solc == nil {
-		return nil, fmt.Errorf("solc instance must be provided to create new compiler")
+	if err := validateCompilerArgs(solc, config); err != nil {
+		return nil, err
 	}
 
-	if source == "" {
-		return nil, fmt.Errorf("source code must be provided to create new compiler")
+	// In standard-json mode the source is the json input, whose sources are normalized instead.
+	if config.IsNormalizeSource() && config.JsonConfig != nil {
+		normalized, err := normalizeJsonSources(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize the standard-json sources: %w", err)
+		}
+		source = normalized
+	} else if config.IsNormalizeSource() {
+		source = normalizeSource(source)
 	}
 
-	if config.JsonConfig == nil {
-		if err := config.Validate(); err != nil {
-			return nil, fmt.Errorf("invalid compiler configuration: %w", err)
-		}
+	if err := config.checkSourceSize(int64(len(source))); err != nil {
+		return nil, err
 	}
 
 	return &Compiler{
@@ -48,11 +80,77 @@ func NewCompiler(ctx context.Context, solc *Solc, config *CompilerConfig, source
 	}, nil
 }
 
+// NewCompilerFromReader creates a new Compiler instance reading the source from the provided reader. The source
+// is streamed into solc rather than buffered in memory, so the reader is consumed by the first compilation and
+// the compiler can't be reused. GetSources returns an empty string for such compilers.
+func NewCompilerFromReader(ctx context.Context, solc *Solc, config *CompilerConfig, r io.Reader) (*Compiler, error) {
+	if r == nil {
+		return nil, fmt.Errorf("source reader must be provided to create new compiler")
+	}
+
+	if err := validateCompilerArgs(solc, config); err != nil {
+		return nil, err
+	}
+
+	return &Compiler{
+		ctx:    ctx,
+		reader: r,
+		config: config,
+		solc:   solc,
+	}, nil
+}
+
+// normalizeSource strips a leading UTF-8 BOM from the source and converts its CRLF line endings to LF.
+func normalizeSource(source string) string {
+	source = strings.TrimPrefix(source, "\uFEFF")
+	return strings.ReplaceAll(source, "\r\n", "\n")
+}
+
+// validateCompilerArgs validates the solc instance and the configuration provided to create a new compiler.
+func validateCompilerArgs(solc *Solc, config *CompilerConfig) error {
+	if config == nil {
+		return fmt.Errorf("config must be provided to create new compiler")
+	}
+
+	if solc == nil {
+		return fmt.Errorf("solc instance must be provided to create new compiler")
+	}
+
+	if config.JsonConfig == nil {
+		if err := config.Validate(); err != nil {
+			return fmt.Errorf("invalid compiler configuration: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // SetCompilerVersion sets the version of the solc compiler to use.
 func (v *Compiler) SetCompilerVersion(version string) {
 	v.config.SetCompilerVersion(version)
 }
 
+// UseVersion sets the version of the solc compiler to use after validating it's a known release whose binary is
+// installed. Unlike SetCompilerVersion, a mistyped or missing version is reported immediately rather than at
+// compile time. The "latest", "stable" and "nightly" keywords are accepted and kept unresolved.
+func (v *Compiler) UseVersion(version string) error {
+	resolved, err := v.solc.ResolveVersion(version)
+	if err != nil {
+		return err
+	}
+
+	if _, err := v.solc.GetRelease(resolved); err != nil {
+		return fmt.Errorf("solc version %s is not a known release: %w", version, err)
+	}
+
+	if !v.solc.IsInstalled(resolved) {
+		return fmt.Errorf("solc version %s is not installed, sync it first", version)
+	}
+
+	v.SetCompilerVersion(getCleanedVersionTag(version))
+	return nil
+}
+
 // GetCompilerVersion returns the currently set version of the solc compiler.
 func (v *Compiler) GetCompilerVersion() string {
 	return v.config.GetCompilerVersion()
@@ -68,38 +166,277 @@ func (v *Compiler) GetSources() string {
 	return v.source
 }
 
-// Compile compiles the Solidity sources using the configured compiler version and arguments.
-// It returns the compilation results or an error if the compilation fails.
-func (v *Compiler) Compile() (*CompilerResults, error) {
-	compilerVersion := v.GetCompilerVersion()
-	if compilerVersion == "" {
-		return nil, fmt.Errorf("no compiler version specified")
+// input returns the source passed to solc on stdin. Error recovery of standard-json compilations is enabled within
+// the input itself, as solc ignores the --error-recovery argument in standard-json mode.
+func (v *Compiler) input() (string, error) {
+	if v.config.JsonConfig == nil || !v.config.IsErrorRecovery() {
+		return v.source, nil
 	}
 
-	binaryPath, err := v.solc.GetBinary(compilerVersion)
+	input, err := withParserErrorRecovery(v.source)
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("failed to enable error recovery in the standard-json input: %w", err)
+	}
+
+	return input, nil
+}
+
+// Validate checks that the compilation is ready to run without running solc. It ensures the compiler version is
+// set, its binary is installed, and the arguments and the JSON config are coherent. It allows failing fast before
+// committing to a long-running compilation job.
+func (v *Compiler) Validate() error {
+	_, _, _, err := v.prepare()
+	return err
+}
+
+// prepare resolves the concrete compiler version, the binary and the sanitized arguments of the compilation,
+// validating the configuration. Version keywords such as "latest" are resolved once, here.
+func (v *Compiler) prepare() (string, string, []string, error) {
+	if v.GetCompilerVersion() == "" {
+		return "", "", nil, fmt.Errorf("no compiler version specified")
 	}
 
-	args := []string{}
-	sanitizedArgs, err := v.config.SanitizeArguments(v.config.Arguments)
+	compilerVersion, err := v.solc.ResolveVersion(v.GetCompilerVersion())
 	if err != nil {
-		return nil, err
+		return "", "", nil, err
+	}
+
+	// The configuration may have changed since the compiler was created. Streamed sources are checked while read.
+	if v.reader == nil {
+		if err := v.config.checkSourceSize(int64(len(v.source))); err != nil {
+			return "", "", nil, err
+		}
+	}
+
+	if v.config.IsCheckVersionPragma() {
+		if err := v.checkVersionPragmas(compilerVersion); err != nil {
+			return "", "", nil, err
+		}
+	}
+
+	binaryPath, binaryArgs, err := v.resolveBinary(compilerVersion)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	args, err := v.config.SanitizeArguments(v.config.GetCompileArguments())
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	if len(binaryArgs) > 0 && v.config.JsonConfig == nil {
+		return "", "", nil, fmt.Errorf("solcjs fallback for version %s requires a json config", compilerVersion)
+	}
+
+	if v.config.IsCheckFlagSupport() {
+		for _, arg := range args {
+			flag, _, _ := strings.Cut(arg, "=")
+			if strings.HasPrefix(flag, "--") && !v.solc.SupportsFlag(compilerVersion, flag) {
+				return "", "", nil, fmt.Errorf("%w: flag %s not supported by solc %s", ErrUnsupportedFlag, flag, compilerVersion)
+			}
+		}
 	}
-	args = append(args, sanitizedArgs...)
 
 	if v.config.JsonConfig == nil {
 		if err := v.config.Validate(); err != nil {
-			return nil, err
+			return "", "", nil, err
+		}
+	} else {
+		if !containsArgument(args, "--standard-json") {
+			return "", "", nil, fmt.Errorf("json config requires the --standard-json argument")
 		}
+
+		// Streamed sources can't be inspected upfront, solc reports invalid input itself.
+		if v.reader == nil && !json.Valid([]byte(v.source)) {
+			return "", "", nil, fmt.Errorf("json config requires the source to be a standard-json input")
+		}
+
+		if v.reader != nil && v.config.IsErrorRecovery() {
+			return "", "", nil, fmt.Errorf("error recovery with a json config can't be enabled for streamed sources")
+		}
+	}
+
+	return compilerVersion, binaryPath, append(binaryArgs, args...), nil
+}
+
+// checkVersionPragmas returns ErrVersionPragmaMismatch if the compiler version doesn't satisfy the version pragma
+// of one of the sources. Streamed sources can't be inspected upfront and pragmas which can't be parsed are left
+// for solc to report.
+func (v *Compiler) checkVersionPragmas(compilerVersion string) error {
+	sources := map[string]string{"<stdin>": v.source}
+	if v.config.JsonConfig != nil {
+		sources = make(map[string]string, len(v.config.JsonConfig.Sources))
+		for name, source := range v.config.JsonConfig.Sources {
+			sources[name] = source.Content
+			if v.config.IsNormalizeSource() {
+				sources[name] = normalizeSource(source.Content)
+			}
+		}
+	} else if v.reader != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, pragma := range ExtractVersionPragmas(sources[name]) {
+			satisfied, err := SatisfiesVersionPragma(compilerVersion, pragma)
+			if err == nil && !satisfied {
+				return fmt.Errorf(
+					"%w: %s requires solidity %s, compiler version is %s", ErrVersionPragmaMismatch, name, pragma, compilerVersion,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveBinary returns the path of the solc binary to run, along with the leading arguments it must be run with.
+// The binary path set in the configuration takes precedence over the installed release of the compiler version.
+// Versions falling back to solcjs run Node, with the wrapper and the soljson.js build as leading arguments.
+func (v *Compiler) resolveBinary(compilerVersion string) (string, []string, error) {
+	binaryPath := v.config.GetBinaryPath()
+	if binaryPath == "" {
+		if v.solc.GetBinarySource(compilerVersion) == BinarySourceSolcJS {
+			return v.solc.solcJSCommand(compilerVersion)
+		}
+
+		binaryPath, err := v.solc.GetBinary(compilerVersion)
+		return binaryPath, nil, err
+	}
+
+	info, err := os.Stat(binaryPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("custom solc binary not found: %w", err)
+	}
+
+	if info.IsDir() {
+		return "", nil, fmt.Errorf("custom solc binary is a directory: %s", binaryPath)
+	}
+
+	return binaryPath, nil, nil
+}
+
+// Compile compiles the Solidity sources using the configured compiler version and arguments.
+// It returns the compilation results or an error if the compilation fails.
+func (v *Compiler) Compile() (*CompilerResults, error) {
+	return v.CompileWithContext(v.ctx)
+}
+
+// CompileWithContext compiles the Solidity sources like Compile, but under the provided context instead of the one
+// the compiler was created with. It allows compiling the same compiler under different deadlines.
+func (v *Compiler) CompileWithContext(ctx context.Context) (*CompilerResults, error) {
+	hooks := v.solc.GetConfig().GetHooks()
+	hooks.compileStart(v.GetCompilerVersion())
+
+	started := time.Now()
+	results, err := v.compile(ctx)
+	if err != nil && v.shouldRepairBinary(ctx, err) {
+		results, err = v.repairAndCompile(ctx, err)
+	}
+	hooks.compileEnd(v.GetCompilerVersion(), time.Since(started), err)
+
+	return results, err
+}
+
+// shouldRepairBinary reports whether the compilation failed because the installed binary is corrupt, i.e. it
+// couldn't be executed or crashed, and the binary is to be repaired as configured. Streamed sources can't be
+// compiled twice, so they're never retried.
+func (v *Compiler) shouldRepairBinary(ctx context.Context, err error) bool {
+	config := v.solc.GetConfig()
+	if !config.IsAutoRepairBinaries() || config.IsOffline() || v.reader != nil || v.config.GetBinaryPath() != "" {
+		return false
+	}
+
+	// A cancelled context kills solc, which isn't the binary's fault.
+	if ctx != nil && ctx.Err() != nil {
+		return false
+	}
+
+	var execErr *CompileExecError
+	if errors.As(err, &execErr) {
+		return execErr.IsCrash()
+	}
+
+	return errors.Is(err, syscall.ENOEXEC)
+}
+
+// repairAndCompile downloads again the binary the compilation failed with, and retries the compilation once.
+func (v *Compiler) repairAndCompile(ctx context.Context, compileErr error) (*CompilerResults, error) {
+	compilerVersion, err := v.solc.ResolveVersion(v.GetCompilerVersion())
+	if err != nil || v.solc.GetBinarySource(compilerVersion) != BinarySourceNative {
+		return nil, compileErr
+	}
+
+	logger := v.solc.GetConfig().GetLogger()
+	logger.Warn(
+		"Repairing corrupt solc binary",
+		zap.String("version", compilerVersion),
+		zap.String("binary_path", v.solc.BinaryPath(compilerVersion)),
+		zap.Error(compileErr),
+	)
+
+	if err := v.solc.repairBinary(compilerVersion); err != nil {
+		return nil, fmt.Errorf("failed to repair binary for version %s: %w (compilation failed with: %w)", compilerVersion, err, compileErr)
+	}
+
+	logger.Info("Repaired corrupt solc binary, retrying the compilation", zap.String("version", compilerVersion))
+
+	return v.compile(ctx)
+}
+
+// compile runs solc under the provided context and parses its output.
+func (v *Compiler) compile(ctx context.Context) (*CompilerResults, error) {
+	compilerVersion, binaryPath, args, err := v.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// The timeout carries its own cause, telling it apart from deadlines of the caller's context.
+	if maxDuration := v.config.GetMaxCompileDuration(); maxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, maxDuration, fmt.Errorf("%w: exceeded %s", ErrCompileTimeout, maxDuration))
+		defer cancel()
 	}
 
 	// #nosec G204
 	// G204 (CWE-78): Subprocess launched with variable (Confidence: HIGH, Severity: MEDIUM)
 	// We did sanitization and verification of the arguments above, so we are safe to use them.
-	cmd := exec.Command(binaryPath, args...)
+	cmd := exec.CommandContext(ctx, binaryPath, args...)
 
-	cmd.Stdin = strings.NewReader(v.source)
+	// Hash the exact source bytes passed to solc, recorded on every result for provenance.
+	hasher := newSourceHasher()
+	var streamed *countingReader
+	if v.reader != nil {
+		if v.readerConsumed {
+			return nil, fmt.Errorf("source reader already consumed by a previous compilation")
+		}
+		v.readerConsumed = true
+
+		// Read at most one byte past the limit, enough to tell the source is too large without buffering it all.
+		reader := v.reader
+		if maxBytes := v.config.GetMaxSourceBytes(); maxBytes > 0 {
+			reader = io.LimitReader(reader, maxBytes+1)
+		}
+		streamed = &countingReader{r: reader}
+		cmd.Stdin = io.TeeReader(streamed, hasher)
+	} else {
+		input, err := v.input()
+		if err != nil {
+			return nil, err
+		}
+		_, _ = hasher.Write([]byte(input))
+		cmd.Stdin = strings.NewReader(input)
+	}
 
 	// Capture the output
 	var out bytes.Buffer
@@ -107,8 +444,30 @@ func (v *Compiler) Compile() (*CompilerResults, error) {
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
-	if err := cmd.Run(); err != nil {
-		zap.L().Error(
+	started := time.Now()
+	err = cmd.Run()
+	duration := time.Since(started)
+
+	// A truncated source may or may not compile, either way the results can't be trusted.
+	if streamed != nil {
+		if sizeErr := v.config.checkSourceSize(streamed.n); sizeErr != nil {
+			return nil, sizeErr
+		}
+	}
+
+	sourceHash := encodeSourceHash(hasher)
+	binaryHash := v.binaryHash(compilerVersion, binaryPath)
+
+	if err != nil {
+		if cause := context.Cause(ctx); errors.Is(cause, ErrCompileTimeout) {
+			return nil, cause
+		}
+
+		if execErr := newCompileExecError(err, stderr.String()); execErr != nil {
+			err = execErr
+		}
+
+		v.solc.GetConfig().GetLogger().Error(
 			"Failed to compile Solidity sources",
 			zap.String("version", compilerVersion),
 			zap.String("stdout", out.String()),
@@ -118,146 +477,480 @@ func (v *Compiler) Compile() (*CompilerResults, error) {
 
 		// Parsing the error message to extract line and column information.
 		errorMessage := stderr.String()
-		errors = append(errors, CompilationError{Message: errorMessage})
+		compilationError := CompilationError{Message: errorMessage}
+		compilationError.annotate()
+		errors = append(errors, compilationError)
 
 		// Construct the CompilerResults structure with errors and warnings.
 		results := &CompilerResult{
-			RequestedVersion: compilerVersion,
-			Errors:           errors,
+			RequestedVersion:   compilerVersion,
+			Errors:             errors,
+			SourceHash:         sourceHash,
+			CompilerBinaryHash: binaryHash,
+			Mode:               v.mode(),
 		}
-		return &CompilerResults{Results: []*CompilerResult{results}}, err
+
+		if compilationError.IsStackTooDeep() {
+			err = fmt.Errorf("%w: %w", ErrStackTooDeep, err)
+		}
+
+		// In error recovery mode solc may still emit partial artifacts, which we return along the recovered errors.
+		if v.config.IsErrorRecovery() && out.Len() > 0 {
+			if partialResults, parseErr := v.parseResults(ctx, compilerVersion, binaryHash, out); parseErr == nil {
+				for _, result := range partialResults.Results {
+					result.Errors = append(result.Errors, errors...)
+					result.SourceHash = sourceHash
+					result.CompilerBinaryHash = binaryHash
+				}
+				partialResults.Results = append(partialResults.Results, results)
+				partialResults.Partial = true
+				partialResults.Duration = duration
+				return partialResults, err
+			}
+		}
+
+		return &CompilerResults{Results: []*CompilerResult{results}, Duration: duration}, err
+	}
+
+	compilerResults, err := v.parseResults(ctx, compilerVersion, binaryHash, out)
+	if err != nil {
+		return nil, err
+	}
+
+	compilerResults.Duration = duration
+	for _, result := range compilerResults.Results {
+		result.SourceHash = sourceHash
+		result.CompilerBinaryHash = binaryHash
+	}
+
+	if v.config.JsonConfig != nil && v.config.IsKeepRawOutput() {
+		compilerResults.RawOutput = out.Bytes()
+	}
+
+	if v.config.JsonConfig != nil && len(v.config.JsonConfig.Sources) > 0 {
+		compilerResults.SourceHashes = make(map[string]string, len(v.config.JsonConfig.Sources))
+		for name, source := range v.config.JsonConfig.Sources {
+			content := source.Content
+			if v.config.IsNormalizeSource() {
+				content = normalizeSource(content)
+			}
+			compilerResults.SourceHashes[name] = HashSource(content)
+		}
+	}
+
+	if v.config.IsWarningsAsErrors() {
+		if warnings := compilerResults.Warnings(); len(warnings) > 0 {
+			return compilerResults, fmt.Errorf("%w: %d warning(s), first: %s", ErrCompilationWarnings, len(warnings), warnings[0].Message)
+		}
+	}
+
+	return compilerResults, nil
+}
+
+// binaryHash returns the SHA-256 checksum of the compiler the compilation ran with: the solc binary, or the soljson
+// build when running through solcjs. It's empty if the checksum can't be computed.
+func (v *Compiler) binaryHash(compilerVersion string, binaryPath string) string {
+	if v.config.GetBinaryPath() == "" && v.solc.GetBinarySource(compilerVersion) == BinarySourceSolcJS {
+		binaryPath = v.solc.SolcJSPath(compilerVersion)
+	}
+
+	checksum, err := fileChecksum(binaryPath)
+	if err != nil {
+		v.solc.GetConfig().GetLogger().Warn(
+			"Failed to compute solc binary checksum",
+			zap.String("version", compilerVersion),
+			zap.String("binary_path", binaryPath),
+			zap.Error(err),
+		)
+		return ""
+	}
+
+	return checksum
+}
+
+// mode returns the compilation mode according to the configuration.
+func (v *Compiler) mode() CompilationMode {
+	if v.config.JsonConfig != nil {
+		return ModeStandardJSON
 	}
 
+	return ModeCombinedJSON
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read reads from the underlying reader, counting the bytes read.
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// parseResults parses the solc output according to the compilation mode. The context and the binary checksum are
+// used to ask the binary for its version when the output doesn't report it.
+func (v *Compiler) parseResults(ctx context.Context, compilerVersion string, binaryHash string, out bytes.Buffer) (*CompilerResults, error) {
 	if v.config.JsonConfig != nil {
-		return v.resultsFromJson(compilerVersion, out)
+		return v.resultsFromJson(ctx, compilerVersion, binaryHash, out)
 	}
 
-	return v.resultsFromSimple(compilerVersion, out)
+	return v.resultsFromSimple(ctx, compilerVersion, binaryHash, out)
 }
 
 // resultsFromSimple parses the output from the solc compiler when the output is in a simple format.
 // It extracts the compilation details such as bytecode, ABI, and any errors or warnings.
 // The method returns a slice of CompilerResults or an error if the output cannot be parsed.
-func (v *Compiler) resultsFromSimple(compilerVersion string, out bytes.Buffer) (*CompilerResults, error) {
+func (v *Compiler) resultsFromSimple(ctx context.Context, compilerVersion string, binaryHash string, out bytes.Buffer) (*CompilerResults, error) {
 	// Parse the output
 	var compilationOutput struct {
 		Contracts map[string]struct {
-			Bin string      `json:"bin"`
-			Abi interface{} `json:"abi"`
+			Bin           string          `json:"bin"`
+			Abi           interface{}     `json:"abi"`
+			SrcMap        string          `json:"srcmap"`
+			SrcMapRuntime string          `json:"srcmap-runtime"`
+			Opcodes       string          `json:"opcodes"`
+			Asm           json.RawMessage `json:"asm"`
 		} `json:"contracts"`
-		Errors  []string `json:"errors"`
-		Version string   `json:"version"`
+		Errors []string `json:"errors"`
+		// Very old solc versions omit the version or shape it differently, hence it's parsed separately.
+		Version json.RawMessage `json:"version"`
 	}
 
 	if err := json.Unmarshal(out.Bytes(), &compilationOutput); err != nil {
 		return nil, err
 	}
 
+	version := combinedJsonVersion(compilationOutput.Version)
+	if version == "" {
+		version = v.binaryVersion(ctx, compilerVersion, binaryHash)
+	}
+
 	// Separate errors and warnings
 	var errors []CompilationError
 	for _, msg := range compilationOutput.Errors {
-		errors = append(errors, CompilationError{Message: msg})
+		compilationError := CompilationError{Message: msg}
+		compilationError.annotate()
+		errors = append(errors, compilationError)
 	}
 
 	var results []*CompilerResult
 
 	for key, output := range compilationOutput.Contracts {
-		isEntryContract := false
-		if v.config.GetEntrySourceName() != "" && key == "<stdin>:"+v.config.GetEntrySourceName() {
-			isEntryContract = true
-		}
+		sourcePath, contractName := splitContractKey(key)
 
-		abi, err := json.Marshal(output.Abi)
+		abi, err := combinedJsonAbi(output.Abi)
 		if err != nil {
 			return nil, err
 		}
 
 		results = append(results, &CompilerResult{
-			IsEntryContract:  isEntryContract,
-			RequestedVersion: compilerVersion,
-			CompilerVersion:  compilationOutput.Version,
-			Bytecode:         output.Bin,
-			ABI:              string(abi),
-			ContractName:     strings.TrimLeft(key, "<stdin>:"),
-			Errors:           errors,
+			Mode:              ModeCombinedJSON,
+			IsEntryContract:   v.config.isEntryContract(sourcePath, contractName, "<stdin>"),
+			RequestedVersion:  compilerVersion,
+			CompilerVersion:   version,
+			Bytecode:          output.Bin,
+			ABI:               abi,
+			ContractName:      contractName,
+			SourcePath:        sourcePath,
+			Errors:            errors,
+			SourceMap:         output.SrcMap,
+			DeployedSourceMap: output.SrcMapRuntime,
+			Opcodes:           output.Opcodes,
+			LegacyAssembly:    legacyAssembly(output.Asm),
 		})
 	}
 
+	markImportedContracts(results, "<stdin>")
+
 	return &CompilerResults{Results: results}, nil
 }
 
+// splitContractKey splits the "source:ContractName" key of the combined-json output into the source path and the
+// contract name. Source paths may contain colons themselves (e.g. Windows drive letters), hence the last one is used.
+func splitContractKey(key string) (string, string) {
+	i := strings.LastIndex(key, ":")
+	if i < 0 {
+		return "", key
+	}
+	return key[:i], key[i+1:]
+}
+
+// legacyAssembly returns the legacy assembly JSON as a string, or an empty string when it wasn't requested.
+func legacyAssembly(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	return string(raw)
+}
+
+// combinedJsonVersion extracts the compiler version from the combined-json "version" field. It returns an empty
+// string when the field is absent or shaped unexpectedly, as is the case with some old solc versions.
+func combinedJsonVersion(raw json.RawMessage) string {
+	var version string
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(version)
+}
+
+// combinedJsonAbi returns the ABI as a JSON string. Old solc versions (e.g. 0.4.x) emit the ABI as an already
+// encoded JSON string instead of an array, in which case it's returned as is.
+func combinedJsonAbi(abi interface{}) (string, error) {
+	if encoded, ok := abi.(string); ok {
+		return encoded, nil
+	}
+
+	marshaled, err := json.Marshal(abi)
+	if err != nil {
+		return "", err
+	}
+
+	return string(marshaled), nil
+}
+
+// solcVersionRegex matches the full version reported by `solc --version`.
+var solcVersionRegex = regexp.MustCompile(`Version:\s*(\S+)`)
+
+// binaryVersion returns the full version reported by `solc --version` for the requested compiler version.
+// It's used as a fallback when the compiler output doesn't report the version, so failures are logged and
+// result in an empty version instead of failing the compilation. Versions are cached by the binary checksum, so
+// that the binary is asked only once rather than on every compilation.
+func (v *Compiler) binaryVersion(ctx context.Context, compilerVersion string, binaryHash string) string {
+	if version, ok := v.solc.cachedBinaryVersion(binaryHash); ok {
+		return version
+	}
+
+	binaryPath, binaryArgs, err := v.resolveBinary(compilerVersion)
+	if err != nil {
+		return ""
+	}
+
+	// #nosec G204
+	// The binary path is resolved by the library and the only argument is a constant.
+	out, err := exec.CommandContext(ctx, binaryPath, append(binaryArgs, "--version")...).Output()
+	if err != nil {
+		v.solc.GetConfig().GetLogger().Warn(
+			"Failed to resolve solc version",
+			zap.String("version", compilerVersion),
+			zap.Error(err),
+		)
+		return ""
+	}
+
+	match := solcVersionRegex.FindSubmatch(out)
+	if match == nil {
+		return ""
+	}
+
+	v.solc.cacheBinaryVersion(binaryHash, string(match[1]))
+	return string(match[1])
+}
+
+// cachedBinaryVersion returns the version previously reported by the binary with the provided checksum.
+func (s *Solc) cachedBinaryVersion(binaryHash string) (string, bool) {
+	s.binaryVersionsMu.Lock()
+	defer s.binaryVersionsMu.Unlock()
+
+	version, ok := s.binaryVersions[binaryHash]
+	return version, ok
+}
+
+// cacheBinaryVersion records the version reported by the binary with the provided checksum. Binaries whose
+// checksum couldn't be computed aren't cached, as they can't be told apart.
+func (s *Solc) cacheBinaryVersion(binaryHash string, version string) {
+	if binaryHash == "" {
+		return
+	}
+
+	s.binaryVersionsMu.Lock()
+	defer s.binaryVersionsMu.Unlock()
+
+	if s.binaryVersions == nil {
+		s.binaryVersions = make(map[string]string)
+	}
+	s.binaryVersions[binaryHash] = version
+}
+
 // resultsFromJson parses the output from the solc compiler when the output is in a JSON format.
 // It extracts detailed compilation information including bytecode, ABI, opcodes, and metadata.
 // Additionally, it separates any errors and warnings from the compilation process.
 // The method returns a slice of CompilerResults or an error if the output cannot be parsed.
-func (v *Compiler) resultsFromJson(compilerVersion string, out bytes.Buffer) (*CompilerResults, error) {
+func (v *Compiler) resultsFromJson(ctx context.Context, compilerVersion string, binaryHash string, out bytes.Buffer) (*CompilerResults, error) {
 	var compilationOutput struct {
-		Contracts map[string]map[string]struct {
-			Abi interface{} `json:"abi"`
-			Evm struct {
-				Bytecode struct {
-					GeneratedSources []interface{}          `json:"generatedSources"`
-					LinkReferences   map[string]interface{} `json:"linkReferences"`
-					Object           string                 `json:"object"`
-					Opcodes          string                 `json:"opcodes"`
-					SourceMap        string                 `json:"sourceMap"`
-				} `json:"bytecode"`
-				DeployedBytecode struct {
-					GeneratedSources []interface{}          `json:"generatedSources"`
-					LinkReferences   map[string]interface{} `json:"linkReferences"`
-					Object           string                 `json:"object"`
-					Opcodes          string                 `json:"opcodes"`
-					SourceMap        string                 `json:"sourceMap"`
-				} `json:"deployedBytecode"`
-			} `json:"evm"`
-			Metadata string `json:"metadata"`
-		} `json:"contracts"`
-		Errors  []CompilationError `json:"errors"`
-		Version string             `json:"version"`
+		Contracts map[string]map[string]jsonContractOutput `json:"contracts"`
+		Errors    []CompilationError                       `json:"errors"`
+		Version   string                                   `json:"version"`
 	}
 
 	if err := json.Unmarshal(out.Bytes(), &compilationOutput); err != nil {
 		return nil, err
 	}
 
+	for i := range compilationOutput.Errors {
+		compilationOutput.Errors[i].annotate()
+	}
+
+	// Standard-json output of solc doesn't necessarily report the version, in which case we ask the binary.
+	version := strings.TrimSpace(compilationOutput.Version)
+	if version == "" {
+		version = v.binaryVersion(ctx, compilerVersion, binaryHash)
+	}
+
 	var results []*CompilerResult
 
-	for key := range compilationOutput.Contracts {
-		for key, output := range compilationOutput.Contracts[key] {
-			isEntryContract := false
-			if v.config.GetEntrySourceName() != "" && key == v.config.GetEntrySourceName() {
-				isEntryContract = true
-			}
+	// Errors are attached to the contracts of the source they're located in. Global errors, and errors located in
+	// sources without contract output, are emitted once within a standalone result.
+	var standaloneErrors []CompilationError
+	for _, compilationError := range compilationOutput.Errors {
+		if _, ok := compilationOutput.Contracts[compilationError.SourceLocation.File]; !ok {
+			standaloneErrors = append(standaloneErrors, compilationError)
+		}
+	}
+
+	for sourceKey := range compilationOutput.Contracts {
+		sourceErrors := errorsForSource(sourceKey, compilationOutput.Errors)
 
-			abi, err := json.Marshal(output.Abi)
+		for key, output := range compilationOutput.Contracts[sourceKey] {
+			result, err := v.resultFromJsonContract(compilerVersion, version, sourceKey, key, output)
 			if err != nil {
 				return nil, err
 			}
 
-			results = append(results, &CompilerResult{
-				IsEntryContract:  isEntryContract,
-				RequestedVersion: compilerVersion,
-				Bytecode:         output.Evm.Bytecode.Object,
-				DeployedBytecode: output.Evm.DeployedBytecode.Object,
-				ABI:              string(abi),
-				Opcodes:          output.Evm.Bytecode.Opcodes,
-				ContractName:     key,
-				Errors:           compilationOutput.Errors,
-				Metadata:         output.Metadata,
-			})
+			result.Errors = sourceErrors
+			result.ModelCheckerDiagnostics = modelCheckerDiagnostics(sourceKey, compilationOutput.Errors)
+			results = append(results, result)
 		}
 	}
 
-	if len(compilationOutput.Errors) > 0 {
+	if len(standaloneErrors) > 0 {
 		results = append(results, &CompilerResult{
+			Mode:             ModeStandardJSON,
 			RequestedVersion: compilerVersion,
-			Errors:           compilationOutput.Errors,
+			CompilerVersion:  version,
+			Errors:           standaloneErrors,
 		})
 	}
 
+	markImportedContracts(results, "")
+
 	return &CompilerResults{Results: results}, nil
 }
 
+// markImportedContracts flags the contracts declared in another source than the entry contracts' ones as imported.
+// Without an entry contract the default source is considered the entry one; when it's empty too, the entry source
+// is unknown and no contract is flagged.
+func markImportedContracts(results []*CompilerResult, defaultSource string) {
+	entrySources := make(map[string]bool)
+	for _, result := range results {
+		if result.IsEntryContract {
+			entrySources[result.SourcePath] = true
+		}
+	}
+
+	if len(entrySources) == 0 {
+		if defaultSource == "" {
+			return
+		}
+		entrySources[defaultSource] = true
+	}
+
+	for _, result := range results {
+		result.IsImportedContract = result.ContractName != "" && !entrySources[result.SourcePath]
+	}
+}
+
+// jsonContractOutput represents the output of a contract within the standard-json output of solc.
+type jsonContractOutput struct {
+	Abi interface{} `json:"abi"`
+	Evm struct {
+		Bytecode struct {
+			GeneratedSources []interface{}          `json:"generatedSources"`
+			LinkReferences   map[string]interface{} `json:"linkReferences"`
+			Object           string                 `json:"object"`
+			Opcodes          string                 `json:"opcodes"`
+			SourceMap        string                 `json:"sourceMap"`
+		} `json:"bytecode"`
+		DeployedBytecode struct {
+			GeneratedSources    []interface{}                   `json:"generatedSources"`
+			LinkReferences      map[string]interface{}          `json:"linkReferences"`
+			ImmutableReferences map[string][]ImmutableReference `json:"immutableReferences"`
+			Object              string                          `json:"object"`
+			Opcodes             string                          `json:"opcodes"`
+			SourceMap           string                          `json:"sourceMap"`
+		} `json:"deployedBytecode"`
+		LegacyAssembly json.RawMessage `json:"legacyAssembly"`
+	} `json:"evm"`
+	Metadata string `json:"metadata"`
+}
+
+// resultFromJsonContract builds the result of the contract declared in the source from its standard-json output,
+// leaving out the errors reported for the source.
+func (v *Compiler) resultFromJsonContract(compilerVersion string, version string, sourceKey string, name string, output jsonContractOutput) (*CompilerResult, error) {
+	abi, err := json.Marshal(output.Abi)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompilerResult{
+		Mode:                ModeStandardJSON,
+		IsEntryContract:     v.config.isEntryContract(sourceKey, name, ""),
+		RequestedVersion:    compilerVersion,
+		CompilerVersion:     version,
+		Bytecode:            output.Evm.Bytecode.Object,
+		DeployedBytecode:    output.Evm.DeployedBytecode.Object,
+		ABI:                 string(abi),
+		Opcodes:             output.Evm.Bytecode.Opcodes,
+		ContractName:        name,
+		SourcePath:          sourceKey,
+		Metadata:            output.Metadata,
+		ImmutableReferences: output.Evm.DeployedBytecode.ImmutableReferences,
+		SourceMap:           output.Evm.Bytecode.SourceMap,
+		DeployedSourceMap:   output.Evm.DeployedBytecode.SourceMap,
+		LegacyAssembly:      legacyAssembly(output.Evm.LegacyAssembly),
+	}, nil
+}
+
+// errorsForSource returns the errors located within the provided source.
+func errorsForSource(sourceKey string, errors []CompilationError) []CompilationError {
+	var sourceErrors []CompilationError
+	for _, err := range errors {
+		if err.SourceLocation.File == sourceKey {
+			sourceErrors = append(sourceErrors, err)
+		}
+	}
+	return sourceErrors
+}
+
+// modelCheckerDiagnostics returns the model checker (SMTChecker) diagnostics reported for the provided source.
+// Diagnostics without a source location are considered global and are returned for every source.
+func modelCheckerDiagnostics(sourceKey string, errors []CompilationError) []CompilationError {
+	var diagnostics []CompilationError
+	for _, err := range errors {
+		if !err.IsModelCheckerDiagnostic() {
+			continue
+		}
+
+		if err.SourceLocation.File != "" && err.SourceLocation.File != sourceKey {
+			continue
+		}
+
+		diagnostics = append(diagnostics, err)
+	}
+
+	return diagnostics
+}
+
+// ImmutableReference represents a single occurrence of an immutable variable within the deployed bytecode.
+type ImmutableReference struct {
+	Start  int `json:"start"`  // The byte offset of the immutable within the deployed bytecode.
+	Length int `json:"length"` // The length of the immutable in bytes.
+}
+
 type CompilationErrorSourceLocation struct {
 	File  string `json:"file"`
 	Start int    `json:"start"`
@@ -272,16 +965,185 @@ type CompilationError struct {
 	Severity       string                         `json:"severity"`
 	Type           string                         `json:"type"`
 	SourceLocation CompilationErrorSourceLocation `json:"sourceLocation"`
+	ErrorCode      string                         `json:"errorCode"`
+	Suggestion     string                         `json:"suggestion,omitempty"`
+}
+
+const (
+	// SuggestionEnableViaIR is a machine-readable hint suggesting to enable the IR-based code generator (viaIR)
+	// or the optimizer, which is the known workaround for the "Stack too deep" error.
+	SuggestionEnableViaIR = "enable-via-ir"
+)
+
+// IsStackTooDeep returns true if the error is the well known "Stack too deep" code generation error.
+func (e CompilationError) IsStackTooDeep() bool {
+	return strings.Contains(e.Message, "Stack too deep") || strings.Contains(e.Formatted, "Stack too deep")
+}
+
+// annotate attaches machine-readable suggestions to errors with known workarounds.
+func (e *CompilationError) annotate() {
+	if e.IsStackTooDeep() {
+		e.Suggestion = SuggestionEnableViaIR
+	}
+}
+
+// IsWarning returns true if the error is a warning. Errors of the simple mode don't carry a severity, in which case
+// the message is inspected instead.
+func (e CompilationError) IsWarning() bool {
+	if e.Severity != "" {
+		return strings.EqualFold(e.Severity, "warning")
+	}
+
+	return strings.Contains(e.Message, "Warning:")
+}
+
+// IsModelCheckerDiagnostic returns true if the error was reported by the model checker (SMTChecker).
+func (e CompilationError) IsModelCheckerDiagnostic() bool {
+	return strings.HasPrefix(e.Message, "CHC:") ||
+		strings.HasPrefix(e.Message, "BMC:") ||
+		strings.Contains(e.Message, "SMTChecker") ||
+		strings.Contains(e.Message, "Model checker")
 }
 
 type CompilerResults struct {
-	Results []*CompilerResult `json:"results"`
+	Results  []*CompilerResult `json:"results"`
+	Duration time.Duration     `json:"duration"` // The time it took solc to compile the sources.
+
+	// SourceHashes holds the keccak256 hash of each compiled source keyed by source name, matching the hashes
+	// recorded in the metadata sources section. It's populated for standard-json compilations only.
+	SourceHashes map[string]string `json:"source_hashes,omitempty"`
+
+	// Partial indicates the results hold partial artifacts emitted in error recovery mode despite errors.
+	Partial bool `json:"partial"`
+
+	// RawOutput holds the exact standard-json output of solc when CompilerConfig.KeepRawOutput is enabled.
+	RawOutput []byte `json:"-"`
+}
+
+// IsPartial returns true if the results hold partial artifacts emitted in error recovery mode despite errors.
+func (cr *CompilerResults) IsPartial() bool {
+	return cr.Partial
+}
+
+// GetRawOutput returns the exact standard-json output of solc, or nil unless CompilerConfig.KeepRawOutput is
+// enabled for a compilation with a json config.
+func (cr *CompilerResults) GetRawOutput() []byte {
+	return cr.RawOutput
+}
+
+// GetSourceHashes returns the keccak256 hash of each compiled source keyed by source name.
+func (cr *CompilerResults) GetSourceHashes() map[string]string {
+	return cr.SourceHashes
+}
+
+// GetDuration returns the time it took solc to compile the sources.
+func (cr *CompilerResults) GetDuration() time.Duration {
+	return cr.Duration
 }
 
 func (cr *CompilerResults) GetResults() []*CompilerResult {
 	return cr.Results
 }
 
+// AsArtifactMap returns the contract results keyed by contract name. Contracts whose name is declared in more
+// than one source are keyed by their fully qualified "source:ContractName" name instead. Results without a
+// contract, such as error-only results, are left out.
+func (cr *CompilerResults) AsArtifactMap() map[string]*CompilerResult {
+	if cr == nil {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	for _, result := range cr.Results {
+		if result.ContractName != "" {
+			counts[result.ContractName]++
+		}
+	}
+
+	artifacts := make(map[string]*CompilerResult, len(counts))
+	for _, result := range cr.Results {
+		if result.ContractName == "" {
+			continue
+		}
+
+		key := result.ContractName
+		if counts[key] > 1 {
+			key = result.SourcePath + ":" + result.ContractName
+		}
+		artifacts[key] = result
+	}
+
+	return artifacts
+}
+
+// AllErrors returns the de-duplicated errors and warnings of all the results, in the order they were reported.
+func (cr *CompilerResults) AllErrors() []CompilationError {
+	if cr == nil {
+		return nil
+	}
+
+	seen := make(map[CompilationError]bool)
+	var errors []CompilationError
+	for _, result := range cr.Results {
+		for _, err := range result.Errors {
+			if seen[err] {
+				continue
+			}
+			seen[err] = true
+			errors = append(errors, err)
+		}
+	}
+
+	return errors
+}
+
+// DeployableContracts returns the results with creation bytecode, leaving out interfaces, abstract contracts and
+// error-only results, which have none. Unlike the entry contract filtering, all the concrete contracts are kept.
+func (cr *CompilerResults) DeployableContracts() []*CompilerResult {
+	if cr == nil {
+		return nil
+	}
+
+	var deployable []*CompilerResult
+	for _, result := range cr.Results {
+		if strings.TrimPrefix(result.Bytecode, "0x") != "" {
+			deployable = append(deployable, result)
+		}
+	}
+
+	return deployable
+}
+
+// Warnings returns the de-duplicated warnings of all the results, in the order they were reported.
+func (cr *CompilerResults) Warnings() []CompilationError {
+	var warnings []CompilationError
+	for _, err := range cr.AllErrors() {
+		if err.IsWarning() {
+			warnings = append(warnings, err)
+		}
+	}
+
+	return warnings
+}
+
+// DiagnosticsBySource returns the de-duplicated errors and warnings of all the results grouped by the source file
+// of their location, each group sorted by start offset. Errors reported without a source location, e.g. by the
+// combined-json mode or for the whole compilation, are grouped under the empty file name.
+func (cr *CompilerResults) DiagnosticsBySource() map[string][]CompilationError {
+	diagnostics := make(map[string][]CompilationError)
+	for _, err := range cr.AllErrors() {
+		diagnostics[err.SourceLocation.File] = append(diagnostics[err.SourceLocation.File], err)
+	}
+
+	for _, errs := range diagnostics {
+		sort.SliceStable(errs, func(i, j int) bool {
+			return errs[i].SourceLocation.Start < errs[j].SourceLocation.Start
+		})
+	}
+
+	return diagnostics
+}
+
 func (cr *CompilerResults) GetEntryContract() *CompilerResult {
 	if cr == nil {
 		return nil
@@ -298,16 +1160,51 @@ func (cr *CompilerResults) GetEntryContract() *CompilerResult {
 
 // CompilerResults represents the results of a solc compilation.
 type CompilerResult struct {
-	IsEntryContract  bool               `json:"is_entry_contract"`
-	RequestedVersion string             `json:"requested_version"`
-	CompilerVersion  string             `json:"compiler_version"`
-	ContractName     string             `json:"contract_name"`
-	Bytecode         string             `json:"bytecode"`
-	DeployedBytecode string             `json:"deployedBytecode"`
-	ABI              string             `json:"abi"`
-	Opcodes          string             `json:"opcodes"`
-	Metadata         string             `json:"metadata"`
-	Errors           []CompilationError `json:"errors"`
+	IsEntryContract    bool               `json:"is_entry_contract"`
+	IsImportedContract bool               `json:"is_imported_contract"`
+	RequestedVersion   string             `json:"requested_version"`
+	CompilerVersion    string             `json:"compiler_version"`
+	ContractName       string             `json:"contract_name"`
+	Bytecode           string             `json:"bytecode"`
+	DeployedBytecode   string             `json:"deployedBytecode"`
+	ABI                string             `json:"abi"`
+	Opcodes            string             `json:"opcodes"`
+	Metadata           string             `json:"metadata"`
+	Errors             []CompilationError `json:"errors"`
+
+	ImmutableReferences     map[string][]ImmutableReference `json:"immutable_references"`
+	ModelCheckerDiagnostics []CompilationError              `json:"model_checker_diagnostics"`
+
+	// SourcePath is the source unit the contract is declared in, "<stdin>" for sources passed via standard input.
+	SourcePath string `json:"source_path"`
+
+	// SourceHash is the "0x" prefixed keccak256 hash of the exact source passed to solc, for provenance records.
+	SourceHash string `json:"source_hash"`
+
+	// SourceMap and DeployedSourceMap map the creation and the deployed bytecode back to the source ranges they
+	// were generated from, for debugging and tracing.
+	SourceMap         string `json:"source_map"`
+	DeployedSourceMap string `json:"deployed_source_map"`
+
+	// LegacyAssembly is the legacy EVM assembly of the contract as a JSON string.
+	LegacyAssembly string `json:"legacy_assembly"`
+
+	// Mode is the pipeline which produced the result, telling which fields are expected to be populated.
+	Mode CompilationMode `json:"mode"`
+
+	// CompilerBinaryHash is the hex encoded SHA-256 checksum of the solc binary which produced the result, tracing
+	// it back to a specific build rather than just a version tag.
+	CompilerBinaryHash string `json:"compiler_binary_hash"`
+}
+
+// GetCompilerBinaryHash returns the SHA-256 checksum of the solc binary which produced the result.
+func (v *CompilerResult) GetCompilerBinaryHash() string {
+	return v.CompilerBinaryHash
+}
+
+// GetMode returns the pipeline which produced the result, combined-json or standard-json.
+func (v *CompilerResult) GetMode() CompilationMode {
+	return v.Mode
 }
 
 // IsEntry returns true if the compiled contract is the entry contract.
@@ -315,6 +1212,12 @@ func (v *CompilerResult) IsEntry() bool {
 	return v.IsEntryContract
 }
 
+// IsImported returns true if the compiled contract is declared in another source than the entry contract, i.e.
+// it comes from a dependency rather than from the compiled source itself.
+func (v *CompilerResult) IsImported() bool {
+	return v.IsImportedContract
+}
+
 // GetOpcodes returns the compiled contract's opcodes.
 func (v *CompilerResult) GetOpcodes() string {
 	return v.Opcodes
@@ -339,6 +1242,42 @@ func (v *CompilerResult) GetErrors() []CompilationError {
 	return v.Errors
 }
 
+// GetImmutableReferences returns the offsets of immutable variables within the deployed bytecode, keyed by the AST id
+// of the immutable variable declaration.
+func (v *CompilerResult) GetImmutableReferences() map[string][]ImmutableReference {
+	return v.ImmutableReferences
+}
+
+// GetModelCheckerDiagnostics returns the model checker (SMTChecker) diagnostics reported for the compiled contract.
+func (v *CompilerResult) GetModelCheckerDiagnostics() []CompilationError {
+	return v.ModelCheckerDiagnostics
+}
+
+// GetSourcePath returns the source unit the contract is declared in.
+func (v *CompilerResult) GetSourcePath() string {
+	return v.SourcePath
+}
+
+// GetSourceHash returns the keccak256 hash of the exact source passed to solc to produce the result.
+func (v *CompilerResult) GetSourceHash() string {
+	return v.SourceHash
+}
+
+// GetSourceMap returns the source map of the compiled contract's creation bytecode.
+func (v *CompilerResult) GetSourceMap() string {
+	return v.SourceMap
+}
+
+// GetDeployedSourceMap returns the source map of the compiled contract's deployed bytecode.
+func (v *CompilerResult) GetDeployedSourceMap() string {
+	return v.DeployedSourceMap
+}
+
+// GetLegacyAssembly returns the compiled contract's legacy EVM assembly as a JSON string.
+func (v *CompilerResult) GetLegacyAssembly() string {
+	return v.LegacyAssembly
+}
+
 // GetABI returns the compiled contract's ABI (Application Binary Interface) in JSON format.
 func (v *CompilerResult) GetABI() string {
 	return v.ABI
@@ -354,6 +1293,19 @@ func (v *CompilerResult) GetDeployedBytecode() string {
 	return v.DeployedBytecode
 }
 
+// DeployedBytecodeSize returns the size in bytes of the deployed bytecode, i.e. half the length of its hex encoding
+// without the optional "0x" prefix. Unlinked library placeholders count as the 20 bytes address they stand for.
+// It's zero when the deployed bytecode isn't part of the compiler output.
+func (v *CompilerResult) DeployedBytecodeSize() int {
+	return len(strings.TrimPrefix(v.DeployedBytecode, "0x")) / 2
+}
+
+// ExceedsSizeLimit returns true if the deployed bytecode exceeds the EIP-170 contract size limit, in which case
+// the contract can't be deployed to mainnet.
+func (v *CompilerResult) ExceedsSizeLimit() bool {
+	return v.DeployedBytecodeSize() > ContractSizeLimit
+}
+
 // GetContractName returns the name of the compiled contract.
 func (v *CompilerResult) GetContractName() string {
 	return v.ContractName
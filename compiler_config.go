@@ -2,10 +2,16 @@ package solc
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 )
 
+// DefaultMaxSourceBytes defines the default maximum size of the source passed to solc.
+const DefaultMaxSourceBytes = 10 * 1024 * 1024
+
 // allowedArgs defines a list of allowed arguments for solc.
 var allowedArgs = map[string]bool{
 	"--combined-json":     true,
@@ -47,6 +53,189 @@ var allowedArgs = map[string]bool{
 	"--metadata-hash":     true,
 	"--metadata-literal":  true,
 	"--error-recovery":    true,
+
+	// Model checker (SMTChecker) arguments.
+	"--model-checker-engine":              true,
+	"--model-checker-targets":             true,
+	"--model-checker-timeout":             true,
+	"--model-checker-contracts":           true,
+	"--model-checker-solvers":             true,
+	"--model-checker-invariants":          true,
+	"--model-checker-ext-calls":           true,
+	"--model-checker-div-mod-no-slacks":   true,
+	"--model-checker-show-unproved":       true,
+	"--model-checker-show-proved-safe":    true,
+	"--model-checker-show-unsupported":    true,
+	"--model-checker-print-query":         true,
+	"--model-checker-bmc-loop-iterations": true,
+}
+
+// combinedJsonFields defines the output fields accepted by the --combined-json argument across solc versions.
+var combinedJsonFields = map[string]bool{
+	"abi":                       true,
+	"asm":                       true,
+	"ast":                       true,
+	"bin":                       true,
+	"bin-runtime":               true,
+	"clone-bin":                 true,
+	"compact-format":            true,
+	"devdoc":                    true,
+	"function-debug":            true,
+	"function-debug-runtime":    true,
+	"generated-sources":         true,
+	"generated-sources-runtime": true,
+	"hashes":                    true,
+	"interface":                 true,
+	"metadata":                  true,
+	"opcodes":                   true,
+	"srcmap":                    true,
+	"srcmap-runtime":            true,
+	"storage-layout":            true,
+	"transient-storage-layout":  true,
+	"userdoc":                   true,
+}
+
+var (
+	// numericValueRegex matches non-negative integer argument values.
+	numericValueRegex = regexp.MustCompile(`^\d+$`)
+
+	// identifierValueRegex matches argument values naming a single option, such as an EVM version.
+	identifierValueRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+
+	// libraryValueRegex matches a single library link, either "source.sol:Library=0x..." or the legacy
+	// "Library:0x..." form.
+	libraryValueRegex = regexp.MustCompile(`^[^\s=]+[=:]0x[0-9a-fA-F]{40}$`)
+)
+
+// argumentValueValidators defines the expected shape of the values of the arguments which take one. Values of
+// the arguments not listed here are only checked against unsafe characters.
+//
+// Threat model: arguments are passed to solc directly, without a shell, so shell injection isn't possible as such.
+// The validation guards against configurations built from untrusted input which could otherwise confuse solc
+// with malformed values (e.g. embedded newlines or null bytes), or widen the files solc may read and write
+// beyond the intended directories through path traversal. It's defense in depth and not a sandbox; solc still
+// runs with the privileges of the calling process.
+var argumentValueValidators = map[string]func(value string) bool{
+	"--optimize-runs":                     numericValueRegex.MatchString,
+	"--model-checker-timeout":             numericValueRegex.MatchString,
+	"--model-checker-bmc-loop-iterations": numericValueRegex.MatchString,
+	"--evm-version":                       identifierValueRegex.MatchString,
+	"--metadata-hash":                     identifierValueRegex.MatchString,
+	"--model-checker-engine":              identifierValueRegex.MatchString,
+	"--combined-json": func(value string) bool {
+		for _, field := range strings.Split(value, ",") {
+			if !combinedJsonFields[field] {
+				return false
+			}
+		}
+		return true
+	},
+	"--libraries": func(value string) bool {
+		links := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' })
+		for _, link := range links {
+			if !libraryValueRegex.MatchString(link) {
+				return false
+			}
+		}
+		return len(links) > 0
+	},
+	"--yul-optimizations": func(value string) bool {
+		return validateYulOptimizations(value) == nil
+	},
+	"--base-path":    isPathValue,
+	"--include-path": isPathValue,
+	"--output-dir":   isPathValue,
+	"--allow-paths": func(value string) bool {
+		for _, path := range strings.Split(value, ",") {
+			if !isPathValue(path) {
+				return false
+			}
+		}
+		return true
+	},
+}
+
+// unsafeValueChars defines characters which are never expected within argument values. Arguments aren't
+// interpreted by a shell, so this is defense in depth against values crafted for shell injection.
+const unsafeValueChars = ";|&$`<>\x00\r\n"
+
+// isPathValue checks whether the value has the shape of a file system path without parent directory traversal.
+func isPathValue(value string) bool {
+	if strings.TrimSpace(value) == "" || strings.ContainsAny(value, unsafeValueChars+"*?") {
+		return false
+	}
+
+	for _, element := range strings.FieldsFunc(value, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if element == ".." {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateArgumentValue checks the value provided for the preceding flag against its expected shape.
+func validateArgumentValue(flag string, value string) error {
+	if strings.ContainsAny(value, unsafeValueChars) {
+		if flag == "" {
+			return fmt.Errorf("invalid argument value: %q", value)
+		}
+		return fmt.Errorf("invalid value for argument %s: %q", flag, value)
+	}
+
+	if validator, ok := argumentValueValidators[flag]; ok && !validator(value) {
+		return fmt.Errorf("invalid value for argument %s: %q", flag, value)
+	}
+
+	return nil
+}
+
+// yulOptimizerSteps defines the abbreviations of the Yul optimizer steps, across solc versions.
+const yulOptimizerSteps = "flcCUnDEvejsxIOoighFTLMmVatrpSudR"
+
+// validateYulOptimizations checks the Yul optimizer step sequence against the grammar accepted by solc: step
+// abbreviations, optionally grouped in balanced "[...]" blocks repeated until a fixpoint, and at most one ":"
+// outside of blocks separating the main sequence from the cleanup sequence. Spaces are ignored.
+func validateYulOptimizations(steps string) error {
+	depth := 0
+	separators := 0
+
+	for _, r := range steps {
+		switch {
+		case r == ' ':
+		case r == '[':
+			depth++
+		case r == ']':
+			if depth == 0 {
+				return fmt.Errorf("invalid yul optimizations %q: unbalanced brackets", steps)
+			}
+			depth--
+		case r == ':':
+			if depth > 0 {
+				return fmt.Errorf("invalid yul optimizations %q: cleanup separator within brackets", steps)
+			}
+			separators++
+			if separators > 1 {
+				return fmt.Errorf("invalid yul optimizations %q: more than one cleanup separator", steps)
+			}
+		case strings.ContainsRune(yulOptimizerSteps, r):
+		default:
+			return fmt.Errorf("invalid yul optimizations %q: unknown step %q", steps, r)
+		}
+	}
+
+	if depth > 0 {
+		return fmt.Errorf("invalid yul optimizations %q: unbalanced brackets", steps)
+	}
+
+	return nil
+}
+
+// metadataHashModes defines the accepted values of the --metadata-hash argument.
+var metadataHashModes = map[string]bool{
+	"none":  true,
+	"ipfs":  true,
+	"bzzr1": true,
 }
 
 // requiredArgs defines a list of required arguments for solc.
@@ -62,6 +251,26 @@ type CompilerConfig struct {
 	EntrySourceName string              // The name of the entry source file.
 	Arguments       []string            // Arguments to pass to the solc tool.
 	JsonConfig      *CompilerJsonConfig // The json config to pass to the solc tool.
+
+	MaxCompileDuration time.Duration // The maximum duration of a single compilation. Zero means unlimited.
+	ErrorRecovery      bool          // Whether solc should continue past recoverable parse errors.
+	CheckVersionPragma bool          // Whether the source version pragmas are checked before invoking solc.
+	MetadataHash       string        // The hash appended to the bytecode metadata: "none", "ipfs" or "bzzr1".
+	NoOptimizeYul      bool          // Whether the Yul optimizer is disabled.
+	YulOptimizations   string        // The custom Yul optimizer step sequence.
+	MaxSourceBytes     int64         // The maximum source size. Zero means DefaultMaxSourceBytes, negative unlimited.
+	KeepRawOutput      bool          // Whether the raw standard-json output of solc is retained on the results.
+	CheckFlagSupport   bool          // Whether the arguments are checked against the flags supported by the version.
+	WarningsAsErrors   bool          // Whether compilations emitting warnings fail with ErrCompilationWarnings.
+	NormalizeSource    bool          // Whether a leading BOM is stripped and CRLF line endings converted to LF.
+
+	AllowedArguments []string // Additional arguments allowed on top of the global allowlist.
+	BinaryPath       string   // Path to a custom solc executable used instead of the installed release.
+	UnsafeArguments  bool     // Whether flags outside of the allowlist are accepted.
+
+	BasePath     string   // The root of the source tree imports are resolved against.
+	IncludePaths []string // Additional directories imports are resolved against, e.g. node_modules.
+	AllowedPaths []string // Additional directories solc is allowed to read imported sources from.
 }
 
 // NewDefaultCompilerConfig creates and returns a default CompilerConfiguration for compiler to use.
@@ -107,6 +316,40 @@ func NewCompilerConfigFromJSON(compilerVersion string, entrySourceName string, c
 	return toReturn, nil
 }
 
+// NewCompilerConfigFromFiles creates a standard-json compiler configuration out of the Solidity files at the provided
+// paths. Sources are keyed by their path, as provided and with forward slashes, which is the source unit name
+// imports and the entry source name refer to. The default output selection is used unless the settings provide one.
+func NewCompilerConfigFromFiles(compilerVersion string, entrySourceName string, paths []string, settings Settings) (*CompilerConfig, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("at least one source file needs to be provided")
+	}
+
+	sources := make(map[string]Source, len(paths))
+	for _, path := range paths {
+		name := filepath.ToSlash(filepath.Clean(path))
+		if _, ok := sources[name]; ok {
+			return nil, fmt.Errorf("duplicate source file: %s", path)
+		}
+
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read source file: %w", err)
+		}
+
+		sources[name] = Source{Content: string(content)}
+	}
+
+	if len(settings.OutputSelection) == 0 {
+		settings.OutputSelection = DefaultOutputSelection()
+	}
+
+	return NewCompilerConfigFromJSON(compilerVersion, entrySourceName, &CompilerJsonConfig{
+		Language: "Solidity",
+		Sources:  sources,
+		Settings: settings,
+	})
+}
+
 // SetJsonConfig sets the json config to pass to the solc tool.
 func (c *CompilerConfig) SetJsonConfig(config *CompilerJsonConfig) {
 	c.JsonConfig = config
@@ -117,16 +360,32 @@ func (c *CompilerConfig) GetJsonConfig() *CompilerJsonConfig {
 	return c.JsonConfig
 }
 
-// SetEntrySourceName sets the name of the entry source file.
+// SetEntrySourceName sets the entry contract, either as a bare contract name, e.g. "Token", or as a fully qualified
+// name, e.g. "contracts/Token.sol:Token", which tells apart contracts of the same name declared in different sources.
 func (c *CompilerConfig) SetEntrySourceName(name string) {
 	c.EntrySourceName = name
 }
 
-// GetEntrySourceName returns the name of the entry source file.
+// GetEntrySourceName returns the entry contract, either bare or fully qualified.
 func (c *CompilerConfig) GetEntrySourceName() string {
 	return c.EntrySourceName
 }
 
+// isEntryContract reports whether the contract declared in the source is the configured entry contract. A bare
+// entry contract name matches contracts of the default source only, or of any source if the default is empty.
+func (c *CompilerConfig) isEntryContract(sourcePath string, contractName string, defaultSource string) bool {
+	entry := c.GetEntrySourceName()
+	if entry == "" {
+		return false
+	}
+
+	if i := strings.LastIndex(entry, ":"); i >= 0 {
+		return sourcePath == entry[:i] && contractName == entry[i+1:]
+	}
+
+	return contractName == entry && (defaultSource == "" || sourcePath == defaultSource)
+}
+
 // SetCompilerVersion sets the version of the solc compiler to use.
 func (c *CompilerConfig) SetCompilerVersion(version string) {
 	c.CompilerVersion = version
@@ -137,17 +396,393 @@ func (c *CompilerConfig) GetCompilerVersion() string {
 	return c.CompilerVersion
 }
 
-// SanitizeArguments sanitizes the provided arguments against a list of allowed arguments.
-// Returns an error if any of the provided arguments are not in the allowed list.
+// SetMaxCompileDuration sets the maximum duration of a single compilation. The solc process is killed once the
+// duration is exceeded. Zero, the default, means unlimited.
+func (c *CompilerConfig) SetMaxCompileDuration(duration time.Duration) {
+	c.MaxCompileDuration = duration
+}
+
+// GetMaxCompileDuration returns the maximum duration of a single compilation.
+func (c *CompilerConfig) GetMaxCompileDuration() time.Duration {
+	return c.MaxCompileDuration
+}
+
+// SetMaxSourceBytes sets the maximum size in bytes of the source passed to solc, protecting services compiling
+// untrusted uploads from exhausting memory. Zero restores DefaultMaxSourceBytes and a negative value disables the
+// limit. In standard-json mode the limit applies to the whole json input.
+func (c *CompilerConfig) SetMaxSourceBytes(n int64) {
+	c.MaxSourceBytes = n
+}
+
+// GetMaxSourceBytes returns the maximum size in bytes of the source passed to solc, negative when unlimited.
+func (c *CompilerConfig) GetMaxSourceBytes() int64 {
+	if c.MaxSourceBytes == 0 {
+		return DefaultMaxSourceBytes
+	}
+
+	return c.MaxSourceBytes
+}
+
+// checkSourceSize returns ErrSourceTooLarge if the source size exceeds the maximum source size.
+func (c *CompilerConfig) checkSourceSize(size int64) error {
+	if maxBytes := c.GetMaxSourceBytes(); maxBytes > 0 && size > maxBytes {
+		return fmt.Errorf("%w: exceeds the maximum of %d bytes", ErrSourceTooLarge, maxBytes)
+	}
+
+	return nil
+}
+
+// SetErrorRecovery enables or disables the solc error recovery mode. In error recovery mode solc continues past
+// recoverable parse errors and emits partial artifacts where possible, returned along the recovered errors. With a
+// json config it's enabled through settings.parserErrorRecovery of the standard-json input rather than an argument.
+func (c *CompilerConfig) SetErrorRecovery(enabled bool) {
+	c.ErrorRecovery = enabled
+}
+
+// IsErrorRecovery returns true if the solc error recovery mode is enabled.
+func (c *CompilerConfig) IsErrorRecovery() bool {
+	return c.ErrorRecovery
+}
+
+// SetKeepRawOutput enables or disables retaining the raw standard-json output of solc on the results, available
+// through CompilerResults.GetRawOutput. It only applies to compilations with a json config.
+func (c *CompilerConfig) SetKeepRawOutput(keep bool) {
+	c.KeepRawOutput = keep
+}
+
+// IsKeepRawOutput returns true if the raw standard-json output of solc is retained on the results.
+func (c *CompilerConfig) IsKeepRawOutput() bool {
+	return c.KeepRawOutput
+}
+
+// SetWarningsAsErrors enables or disables treating warnings as errors. When enabled, a compilation emitting any
+// warning returns ErrCompilationWarnings along with the results, which are still populated for inspection.
+func (c *CompilerConfig) SetWarningsAsErrors(enabled bool) {
+	c.WarningsAsErrors = enabled
+}
+
+// IsWarningsAsErrors returns true if warnings are treated as errors.
+func (c *CompilerConfig) IsWarningsAsErrors() bool {
+	return c.WarningsAsErrors
+}
+
+// SetNormalizeSource enables or disables the normalization of the source passed to NewCompiler. When enabled, a
+// leading UTF-8 BOM is stripped and CRLF line endings are converted to LF, which older solc versions reject or
+// which shift the reported columns. In standard-json mode the content of every source of the JSON input is
+// normalized.
+func (c *CompilerConfig) SetNormalizeSource(normalize bool) {
+	c.NormalizeSource = normalize
+}
+
+// IsNormalizeSource returns true if the source passed to NewCompiler is normalized.
+func (c *CompilerConfig) IsNormalizeSource() bool {
+	return c.NormalizeSource
+}
+
+// SetCheckFlagSupport enables or disables the check of the arguments against the flags supported by the compiler
+// version, see Solc.SupportsFlag, before invoking solc. An unsupported flag fails with ErrUnsupportedFlag instead of
+// an opaque solc error.
+func (c *CompilerConfig) SetCheckFlagSupport(enabled bool) {
+	c.CheckFlagSupport = enabled
+}
+
+// IsCheckFlagSupport returns true if the arguments are checked against the flags supported by the compiler version.
+func (c *CompilerConfig) IsCheckFlagSupport() bool {
+	return c.CheckFlagSupport
+}
+
+// SetCheckVersionPragma enables or disables the check of the source version pragmas against the compiler version
+// before invoking solc. A mismatch fails with ErrVersionPragmaMismatch instead of a generic solc error.
+func (c *CompilerConfig) SetCheckVersionPragma(enabled bool) {
+	c.CheckVersionPragma = enabled
+}
+
+// IsCheckVersionPragma returns true if the source version pragmas are checked before invoking solc.
+func (c *CompilerConfig) IsCheckVersionPragma() bool {
+	return c.CheckVersionPragma
+}
+
+// SetMetadataHash sets the hash appended to the bytecode metadata, passed as the --metadata-hash argument. The mode
+// must be one of "none", "ipfs" or "bzzr1"; "none" makes the bytecode independent of the metadata, which is needed
+// for reproducible builds. An empty mode keeps the solc default. It only applies to the simple mode, as solc
+// doesn't accept the argument along --standard-json.
+func (c *CompilerConfig) SetMetadataHash(mode string) error {
+	if mode != "" && !metadataHashModes[mode] {
+		return fmt.Errorf("invalid metadata hash mode %q, expected one of none, ipfs or bzzr1", mode)
+	}
+
+	c.MetadataHash = mode
+	return nil
+}
+
+// GetMetadataHash returns the hash appended to the bytecode metadata, or an empty string for the solc default.
+func (c *CompilerConfig) GetMetadataHash() string {
+	return c.MetadataHash
+}
+
+// SetNoOptimizeYul disables or enables the Yul optimizer, passed as the --no-optimize-yul argument. It only applies
+// to the simple mode, as solc doesn't accept the argument along --standard-json.
+func (c *CompilerConfig) SetNoOptimizeYul(disabled bool) {
+	c.NoOptimizeYul = disabled
+}
+
+// IsNoOptimizeYul returns true if the Yul optimizer is disabled.
+func (c *CompilerConfig) IsNoOptimizeYul() bool {
+	return c.NoOptimizeYul
+}
+
+// SetYulOptimizations sets the custom Yul optimizer step sequence, passed as the --yul-optimizations argument,
+// e.g. "dhfoDgvulfnTUtnIf[xa[r]EscLMcCTUtTOntnfDIulLculVcul]jmul[jul]VcTOcul jmul:fDnTOcmu". The sequence is
+// validated against the solc grammar. It's only taken into account by solc along --optimize, and only applies
+// to the simple mode. An empty sequence keeps the solc default.
+func (c *CompilerConfig) SetYulOptimizations(steps string) error {
+	if err := validateYulOptimizations(steps); err != nil {
+		return err
+	}
+
+	c.YulOptimizations = steps
+	return nil
+}
+
+// GetYulOptimizations returns the custom Yul optimizer step sequence, or an empty string for the solc default.
+func (c *CompilerConfig) GetYulOptimizations() string {
+	return c.YulOptimizations
+}
+
+// AddCombinedJsonOutputs adds the provided fields, e.g. "srcmap" and "srcmap-runtime", to the output selection of
+// the --combined-json argument, extending the default "bin,abi" selection of the simple mode. Fields already
+// selected are skipped. It fails if the fields aren't known to solc or the configuration doesn't use the simple mode.
+func (c *CompilerConfig) AddCombinedJsonOutputs(fields ...string) error {
+	for _, field := range fields {
+		if !combinedJsonFields[field] {
+			return fmt.Errorf("invalid combined-json output: %s", field)
+		}
+	}
+
+	for i, arg := range c.Arguments {
+		if arg != "--combined-json" || i+1 >= len(c.Arguments) {
+			continue
+		}
+
+		selected := strings.Split(c.Arguments[i+1], ",")
+		for _, field := range fields {
+			if !containsArgument(selected, field) {
+				selected = append(selected, field)
+			}
+		}
+
+		// Do not mutate the arguments slice which may be shared with a copied configuration.
+		args := append([]string{}, c.Arguments...)
+		args[i+1] = strings.Join(selected, ",")
+		c.Arguments = args
+		return nil
+	}
+
+	return fmt.Errorf("combined-json outputs require the --combined-json argument")
+}
+
+// SetBasePath sets the root of the source tree imports are resolved against, passed as the --base-path argument.
+// The path has to be an existing directory and is stored as an absolute path.
+func (c *CompilerConfig) SetBasePath(path string) error {
+	path, err := absoluteDirectory(path)
+	if err != nil {
+		return fmt.Errorf("invalid base path: %w", err)
+	}
+
+	c.BasePath = path
+	return nil
+}
+
+// GetBasePath returns the root of the source tree imports are resolved against.
+func (c *CompilerConfig) GetBasePath() string {
+	return c.BasePath
+}
+
+// AddIncludePath adds a directory imports are resolved against on top of the base path, e.g. node_modules,
+// passed as an --include-path argument. The path has to be an existing directory and is stored as an absolute
+// path. Include paths require a base path and solc 0.8.8 or newer.
+func (c *CompilerConfig) AddIncludePath(path string) error {
+	path, err := absoluteDirectory(path)
+	if err != nil {
+		return fmt.Errorf("invalid include path: %w", err)
+	}
+
+	if !containsArgument(c.IncludePaths, path) {
+		c.IncludePaths = append(c.IncludePaths, path)
+	}
+
+	return nil
+}
+
+// GetIncludePaths returns the directories imports are resolved against on top of the base path.
+func (c *CompilerConfig) GetIncludePaths() []string {
+	return c.IncludePaths
+}
+
+// AddAllowedPath adds a directory solc is allowed to read imported sources from, passed within the --allow-paths
+// argument. The path has to be an existing directory and is stored as an absolute path.
+func (c *CompilerConfig) AddAllowedPath(path string) error {
+	path, err := absoluteDirectory(path)
+	if err != nil {
+		return fmt.Errorf("invalid allowed path: %w", err)
+	}
+
+	if !containsArgument(c.AllowedPaths, path) {
+		c.AllowedPaths = append(c.AllowedPaths, path)
+	}
+
+	return nil
+}
+
+// GetAllowedPaths returns the directories solc is allowed to read imported sources from.
+func (c *CompilerConfig) GetAllowedPaths() []string {
+	return c.AllowedPaths
+}
+
+// absoluteDirectory returns the absolute path of the directory, checking it exists and is accepted as an
+// argument value.
+func absoluteDirectory(path string) (string, error) {
+	if err := validatePath(path); err != nil {
+		return "", err
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	if !isPathValue(path) {
+		return "", fmt.Errorf("path can't be passed to solc: %q", path)
+	}
+
+	return path, nil
+}
+
+// GetCompileArguments returns the arguments passed to the solc tool, including the ones derived from the typed
+// configuration options.
+func (c *CompilerConfig) GetCompileArguments() []string {
+	args := append([]string{}, c.Arguments...)
+
+	if c.ErrorRecovery && c.JsonConfig == nil && !containsArgument(args, "--error-recovery") {
+		args = append(args, "--error-recovery")
+	}
+
+	if c.MetadataHash != "" && c.JsonConfig == nil && !containsArgument(args, "--metadata-hash") {
+		args = append(args, "--metadata-hash", c.MetadataHash)
+	}
+
+	if c.NoOptimizeYul && c.JsonConfig == nil && !containsArgument(args, "--no-optimize-yul") {
+		args = append(args, "--no-optimize-yul")
+	}
+
+	if c.YulOptimizations != "" && c.JsonConfig == nil && !containsArgument(args, "--yul-optimizations") {
+		args = append(args, "--yul-optimizations", c.YulOptimizations)
+	}
+
+	if c.BasePath != "" && !containsArgument(args, "--base-path") {
+		args = append(args, "--base-path", c.BasePath)
+	}
+
+	for _, path := range c.IncludePaths {
+		args = append(args, "--include-path", path)
+	}
+
+	if len(c.AllowedPaths) > 0 {
+		args = appendAllowedPaths(args, c.AllowedPaths)
+	}
+
+	return args
+}
+
+// appendAllowedPaths adds the paths to the value of the --allow-paths argument, which solc accepts only once,
+// appending the argument when it's not present yet.
+func appendAllowedPaths(args []string, paths []string) []string {
+	for i, arg := range args {
+		if arg == "--allow-paths" && i+1 < len(args) {
+			args[i+1] = strings.Join(append([]string{args[i+1]}, paths...), ",")
+			return args
+		}
+	}
+
+	return append(args, "--allow-paths", strings.Join(paths, ","))
+}
+
+// containsArgument checks whether the argument is present within the provided arguments.
+func containsArgument(args []string, arg string) bool {
+	for _, a := range args {
+		if a == arg {
+			return true
+		}
+	}
+	return false
+}
+
+// SetBinaryPath sets the path to a custom solc executable, such as a debug or patched build, used instead of the
+// installed release of the compiler version. The compiler version is still used to label the results.
+func (c *CompilerConfig) SetBinaryPath(path string) {
+	c.BinaryPath = path
+}
+
+// GetBinaryPath returns the path to the custom solc executable, or an empty string when releases are used.
+func (c *CompilerConfig) GetBinaryPath() string {
+	return c.BinaryPath
+}
+
+// AllowArgument extends the allowlist of this configuration with the provided solc flag. It allows using flags
+// introduced by newer solc versions without waiting for a release of this library.
+func (c *CompilerConfig) AllowArgument(arg string) error {
+	if !strings.HasPrefix(arg, "-") || strings.ContainsAny(arg, " \t\n=") {
+		return fmt.Errorf("invalid argument to allow: %q", arg)
+	}
+
+	if !c.IsArgumentAllowed(arg) {
+		c.AllowedArguments = append(c.AllowedArguments, arg)
+	}
+
+	return nil
+}
+
+// AllowUnsafeArguments enables or disables accepting any flag, skipping the allowlist check. It's an explicit
+// opt-in escape hatch for bleeding-edge solc flags; flags and values are still guarded against shell metacharacters.
+func (c *CompilerConfig) AllowUnsafeArguments(allow bool) {
+	c.UnsafeArguments = allow
+}
+
+// IsUnsafeArgumentsAllowed returns true if flags outside of the allowlist are accepted.
+func (c *CompilerConfig) IsUnsafeArgumentsAllowed() bool {
+	return c.UnsafeArguments
+}
+
+// GetAllowedArguments returns the additional arguments allowed for this configuration.
+func (c *CompilerConfig) GetAllowedArguments() []string {
+	return c.AllowedArguments
+}
+
+// IsArgumentAllowed checks whether the argument is allowed either globally or by this configuration.
+func (c *CompilerConfig) IsArgumentAllowed(arg string) bool {
+	return allowedArgs[arg] || containsArgument(c.AllowedArguments, arg)
+}
+
+// SanitizeArguments sanitizes the provided arguments against a list of allowed arguments and checks the shape
+// of their values. Returns an error if any of the provided arguments are not in the allowed list or any value
+// doesn't match its expected shape.
 func (c *CompilerConfig) SanitizeArguments(args []string) ([]string, error) {
 	var sanitizedArgs []string
+	previous := ""
 	for _, arg := range args {
-		if strings.Contains(arg, "-") {
-			if _, ok := allowedArgs[arg]; !ok {
+		// Only flags are checked against the allowlist; values such as paths may legitimately contain dashes.
+		if strings.HasPrefix(arg, "-") {
+			if strings.ContainsAny(arg, unsafeValueChars) {
+				return nil, fmt.Errorf("invalid argument: %q", arg)
+			}
+			if !c.UnsafeArguments && !c.IsArgumentAllowed(arg) {
 				return nil, fmt.Errorf("invalid argument: %s", arg)
 			}
+		} else if err := validateArgumentValue(previous, arg); err != nil {
+			return nil, err
 		}
 		sanitizedArgs = append(sanitizedArgs, arg)
+		previous = arg
 	}
 	return sanitizedArgs, nil
 }
@@ -173,7 +808,7 @@ func (c *CompilerConfig) Validate() error {
 	}
 
 	matched, _ := regexp.MatchString(`^(\d+\.\d+\.\d+)$`, c.CompilerVersion)
-	if !matched {
+	if !matched && !isVersionAlias(c.CompilerVersion) {
 		return fmt.Errorf("invalid compiler version: %s", c.CompilerVersion)
 	}
 
@@ -1,6 +1,8 @@
 package solc
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,6 +29,60 @@ func TestCompilerConfigSanitizeArguments(t *testing.T) {
 			want:    nil,
 			wantErr: "invalid argument: --invalid",
 		},
+		{
+			name:    "Valid Argument Values",
+			args:    []string{"--optimize-runs", "200", "--combined-json", "bin,abi,srcmap", "--base-path", "/tmp/project-a", "-"},
+			want:    []string{"--optimize-runs", "200", "--combined-json", "bin,abi,srcmap", "--base-path", "/tmp/project-a", "-"},
+			wantErr: "",
+		},
+		{
+			name:    "Non Numeric Optimize Runs",
+			args:    []string{"--optimize-runs", "200; rm -rf /"},
+			want:    nil,
+			wantErr: `invalid value for argument --optimize-runs: "200; rm -rf /"`,
+		},
+		{
+			name:    "Unknown Combined Json Field",
+			args:    []string{"--combined-json", "bin,secrets"},
+			want:    nil,
+			wantErr: `invalid value for argument --combined-json: "bin,secrets"`,
+		},
+		{
+			name:    "Injection In Positional Value",
+			args:    []string{"--optimize", "; rm -rf /"},
+			want:    nil,
+			wantErr: `invalid value for argument --optimize: "; rm -rf /"`,
+		},
+		{
+			name:    "Valid Libraries",
+			args:    []string{"--libraries", "A.sol:L=0x1234567890123456789012345678901234567890,M:0x1234567890123456789012345678901234567890"},
+			want:    []string{"--libraries", "A.sol:L=0x1234567890123456789012345678901234567890,M:0x1234567890123456789012345678901234567890"},
+			wantErr: "",
+		},
+		{
+			name:    "Malformed Libraries",
+			args:    []string{"--libraries", "A.sol:L=0x1234"},
+			want:    nil,
+			wantErr: `invalid value for argument --libraries: "A.sol:L=0x1234"`,
+		},
+		{
+			name:    "Null Byte In Value",
+			args:    []string{"--base-path", "/tmp\x00/etc"},
+			want:    nil,
+			wantErr: `invalid value for argument --base-path: "/tmp\x00/etc"`,
+		},
+		{
+			name:    "Path Traversal",
+			args:    []string{"--allow-paths", "/tmp/project,/tmp/project/../../etc"},
+			want:    nil,
+			wantErr: `invalid value for argument --allow-paths: "/tmp/project,/tmp/project/../../etc"`,
+		},
+		{
+			name:    "Glob In Path",
+			args:    []string{"--allow-paths", "/tmp,/etc/*"},
+			want:    nil,
+			wantErr: `invalid value for argument --allow-paths: "/tmp,/etc/*"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -155,3 +211,191 @@ func TestNewDefaultConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCompilerConfigAllowArgument(t *testing.T) {
+	config := &CompilerConfig{}
+
+	_, err := config.SanitizeArguments([]string{"--new-flag", "-"})
+	assert.EqualError(t, err, "invalid argument: --new-flag")
+
+	assert.NoError(t, config.AllowArgument("--new-flag"))
+	assert.NoError(t, config.AllowArgument("--new-flag"))
+	assert.Equal(t, []string{"--new-flag"}, config.GetAllowedArguments())
+	assert.True(t, config.IsArgumentAllowed("--new-flag"))
+	assert.True(t, config.IsArgumentAllowed("--optimize"))
+
+	got, err := config.SanitizeArguments([]string{"--new-flag", "-"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--new-flag", "-"}, got)
+
+	assert.Error(t, config.AllowArgument("new-flag"))
+	assert.Error(t, config.AllowArgument("--flag value"))
+	assert.Error(t, config.AllowArgument("--flag=value"))
+
+	// Additional arguments are per configuration.
+	assert.False(t, (&CompilerConfig{}).IsArgumentAllowed("--new-flag"))
+}
+
+func TestCompilerConfigAllowUnsafeArguments(t *testing.T) {
+	config := &CompilerConfig{}
+	assert.False(t, config.IsUnsafeArgumentsAllowed())
+
+	_, err := config.SanitizeArguments([]string{"--experimental-flag", "-"})
+	assert.EqualError(t, err, "invalid argument: --experimental-flag")
+
+	config.AllowUnsafeArguments(true)
+	assert.True(t, config.IsUnsafeArgumentsAllowed())
+
+	got, err := config.SanitizeArguments([]string{"--experimental-flag", "value", "-"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"--experimental-flag", "value", "-"}, got)
+
+	// Shell metacharacters are still rejected.
+	_, err = config.SanitizeArguments([]string{"--flag;rm", "-"})
+	assert.Error(t, err)
+
+	_, err = config.SanitizeArguments([]string{"--experimental-flag", "$(rm -rf /)"})
+	assert.Error(t, err)
+}
+
+func TestCompilerConfigSetMetadataHash(t *testing.T) {
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+	assert.Empty(t, config.GetMetadataHash())
+	assert.NotContains(t, config.GetCompileArguments(), "--metadata-hash")
+
+	assert.NoError(t, config.SetMetadataHash("none"))
+	assert.Equal(t, "none", config.GetMetadataHash())
+	assert.Equal(t, []string{"--overwrite", "--combined-json", "bin,abi", "-", "--metadata-hash", "none"}, config.GetCompileArguments())
+
+	_, err = config.SanitizeArguments(config.GetCompileArguments())
+	assert.NoError(t, err)
+
+	assert.ErrorContains(t, config.SetMetadataHash("sha256"), "invalid metadata hash mode")
+	assert.Equal(t, "none", config.GetMetadataHash())
+
+	assert.NoError(t, config.SetMetadataHash(""))
+	assert.NotContains(t, config.GetCompileArguments(), "--metadata-hash")
+
+	jsonConfig, err := NewCompilerConfigFromJSON("0.8.20", "A", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+	assert.NoError(t, jsonConfig.SetMetadataHash("ipfs"))
+	assert.Equal(t, []string{"--standard-json"}, jsonConfig.GetCompileArguments())
+}
+
+func TestCompilerConfigYulOptimizations(t *testing.T) {
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	steps := "dhfoDgvulfnTUtnIf[xa[r]EscLMcCTUtTOntnfDIulLculVcul]jmul[jul]VcTOcul jmul:fDnTOcmu"
+	config.SetNoOptimizeYul(true)
+	assert.True(t, config.IsNoOptimizeYul())
+	assert.NoError(t, config.SetYulOptimizations(steps))
+	assert.Equal(t, steps, config.GetYulOptimizations())
+
+	args := config.GetCompileArguments()
+	assert.Equal(t, []string{"--overwrite", "--combined-json", "bin,abi", "-", "--no-optimize-yul", "--yul-optimizations", steps}, args)
+
+	_, err = config.SanitizeArguments(args)
+	assert.NoError(t, err)
+
+	for _, invalid := range []string{"dhfo[Dg", "dhfo]Dg[", "dh:fo:Dg", "dh[f:o]", "dhfoZ", "dh;rm"} {
+		assert.Error(t, config.SetYulOptimizations(invalid), invalid)
+	}
+	assert.Equal(t, steps, config.GetYulOptimizations())
+
+	_, err = config.SanitizeArguments([]string{"--yul-optimizations", "dh:fo:Dg"})
+	assert.Error(t, err)
+
+	config.SetNoOptimizeYul(false)
+	assert.NoError(t, config.SetYulOptimizations(""))
+	assert.Equal(t, []string{"--overwrite", "--combined-json", "bin,abi", "-"}, config.GetCompileArguments())
+}
+
+func TestCompilerConfigAddCombinedJsonOutputs(t *testing.T) {
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	copied := *config
+	assert.NoError(t, config.AddCombinedJsonOutputs("srcmap", "srcmap-runtime", "abi"))
+	assert.Equal(t, []string{"--overwrite", "--combined-json", "bin,abi,srcmap,srcmap-runtime", "-"}, config.GetCompileArguments())
+	assert.Equal(t, []string{"--overwrite", "--combined-json", "bin,abi", "-"}, copied.GetCompileArguments(), "copies must be left untouched")
+
+	assert.ErrorContains(t, config.AddCombinedJsonOutputs("bytecode"), "invalid combined-json output")
+
+	jsonConfig, err := NewCompilerConfigFromJSON("0.8.20", "A", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+	assert.Error(t, jsonConfig.AddCombinedJsonOutputs("srcmap"))
+}
+
+func TestCompilerConfigImportPaths(t *testing.T) {
+	root := t.TempDir()
+	nodeModules := filepath.Join(root, "node_modules")
+	lib := filepath.Join(root, "lib")
+	assert.NoError(t, os.Mkdir(nodeModules, 0750))
+	assert.NoError(t, os.Mkdir(lib, 0750))
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	assert.ErrorContains(t, config.SetBasePath(filepath.Join(root, "missing")), "invalid base path: path does not exist")
+	assert.Error(t, config.AddIncludePath(filepath.Join(root, "missing")))
+	assert.Error(t, config.AddAllowedPath(filepath.Join(root, "missing")))
+
+	assert.NoError(t, config.SetBasePath(root))
+	assert.NoError(t, config.AddIncludePath(nodeModules))
+	assert.NoError(t, config.AddIncludePath(filepath.Join(root, "lib", "..", "node_modules")), "paths are cleaned")
+	assert.NoError(t, config.AddAllowedPath(nodeModules))
+	assert.NoError(t, config.AddAllowedPath(lib))
+
+	assert.Equal(t, root, config.GetBasePath())
+	assert.Equal(t, []string{nodeModules}, config.GetIncludePaths())
+	assert.Equal(t, []string{nodeModules, lib}, config.GetAllowedPaths())
+
+	args := config.GetCompileArguments()
+	assert.Equal(t, []string{
+		"--overwrite", "--combined-json", "bin,abi", "-",
+		"--base-path", root,
+		"--include-path", nodeModules,
+		"--allow-paths", nodeModules + "," + lib,
+	}, args)
+
+	_, err = config.SanitizeArguments(args)
+	assert.NoError(t, err)
+
+	// Allowed paths are merged into an explicit --allow-paths argument, which solc accepts only once.
+	config.AppendArguments("--allow-paths", "/contracts")
+	assert.Equal(t, []string{"--allow-paths", "/contracts," + nodeModules + "," + lib}, config.GetCompileArguments()[4:6])
+	assert.Equal(t, "/contracts", config.Arguments[5], "arguments must be left untouched")
+}
+
+func TestNewCompilerConfigFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "lib"), 0750))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "Token.sol"), []byte("contract Token {}"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "lib", "Math.sol"), []byte("library Math {}"), 0600))
+
+	paths := []string{filepath.Join(dir, "Token.sol"), filepath.Join(dir, "lib", "Math.sol")}
+	config, err := NewCompilerConfigFromFiles("0.8.20", "Token", paths, Settings{EVMVersion: "paris"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Token", config.GetEntrySourceName())
+	assert.Equal(t, []string{"--standard-json"}, config.GetArguments())
+
+	jsonConfig := config.GetJsonConfig()
+	assert.Equal(t, "Solidity", jsonConfig.Language)
+	assert.Equal(t, "paris", jsonConfig.Settings.EVMVersion)
+	assert.Equal(t, DefaultOutputSelection(), jsonConfig.Settings.OutputSelection)
+	assert.Equal(t, map[string]Source{
+		filepath.ToSlash(paths[0]): {Content: "contract Token {}"},
+		filepath.ToSlash(paths[1]): {Content: "library Math {}"},
+	}, jsonConfig.Sources)
+
+	_, err = NewCompilerConfigFromFiles("0.8.20", "Token", append(paths, paths[0]), Settings{})
+	assert.ErrorContains(t, err, "duplicate source file")
+
+	_, err = NewCompilerConfigFromFiles("0.8.20", "Token", []string{filepath.Join(dir, "Missing.sol")}, Settings{})
+	assert.Error(t, err)
+
+	_, err = NewCompilerConfigFromFiles("0.8.20", "Token", nil, Settings{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+package solc
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ignoredDirs defines directories that are skipped while walking a project directory. Sources within them are
+// only compiled when they're explicitly imported by one of the project sources.
+var ignoredDirs = map[string]bool{
+	"node_modules": true,
+	"lib":          true,
+}
+
+// CompileDir compiles all the Solidity sources found in the provided directory in a single standard-json invocation.
+// The directory is used as the base path, while "node_modules" and "lib" (when present) are used as include paths.
+// Settings from the provided configuration JSON config are used when set, defaults otherwise.
+func (s *Solc) CompileDir(ctx context.Context, dir string, config *CompilerConfig) (*CompilerResults, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config must be provided to compile directory")
+	}
+
+	if err := validatePath(dir); err != nil {
+		return nil, err
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.compileDir(ctx, absDir, nil, config)
+}
+
+// compileDir compiles the Solidity sources of the absolute directory. Known sources, keyed by their path relative
+// to the directory, are used as is instead of being read from the directory.
+func (s *Solc) compileDir(ctx context.Context, absDir string, known map[string]Source, config *CompilerConfig) (*CompilerResults, error) {
+	includePaths := getIncludePaths(absDir)
+
+	sources, err := collectDirSources(absDir, includePaths, known)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no solidity sources found in directory: %s", absDir)
+	}
+
+	jsonConfig := &CompilerJsonConfig{
+		Language: "Solidity",
+		Settings: Settings{OutputSelection: DefaultOutputSelection()},
+	}
+	if config.JsonConfig != nil {
+		jsonConfig.Language = config.JsonConfig.Language
+		jsonConfig.Settings = config.JsonConfig.Settings
+	}
+	jsonConfig.Sources = sources
+
+	args := []string{"--standard-json", "--base-path", absDir}
+	for _, includePath := range includePaths {
+		args = append(args, "--include-path", includePath)
+	}
+
+	dirConfig := *config
+	dirConfig.Arguments = args
+	dirConfig.JsonConfig = jsonConfig
+
+	input, err := jsonConfig.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Compile(ctx, string(input), &dirConfig)
+}
+
+// getIncludePaths returns the existing include path directories for the provided base directory.
+func getIncludePaths(baseDir string) []string {
+	var includePaths []string
+	for _, name := range []string{"node_modules", "lib"} {
+		includePath := filepath.Join(baseDir, name)
+		if info, err := os.Stat(includePath); err == nil && info.IsDir() {
+			includePaths = append(includePaths, includePath)
+		}
+	}
+	return includePaths
+}
+
+// collectDirSources walks the base directory and reads all the Solidity sources, keyed by their path relative to
+// the base directory. Sources within ignored directories are only added when imported by collected sources. Known
+// sources are used as is rather than read again.
+func collectDirSources(baseDir string, includePaths []string, known map[string]Source) (map[string]Source, error) {
+	sources := make(map[string]Source)
+
+	readSource := func(unitName string, filename string) (Source, error) {
+		if source, ok := known[unitName]; ok {
+			return source, nil
+		}
+
+		content, err := os.ReadFile(filepath.Clean(filename))
+		if err != nil {
+			return Source{}, err
+		}
+
+		return Source{Content: string(content)}, nil
+	}
+
+	err := filepath.WalkDir(baseDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != baseDir && ignoredDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(path) != ".sol" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return err
+		}
+
+		source, err := readSource(filepath.ToSlash(rel), path)
+		if err != nil {
+			return err
+		}
+
+		sources[filepath.ToSlash(rel)] = source
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Follow imports of collected sources so that explicitly imported files from ignored directories are compiled.
+	queue := make([]string, 0, len(sources))
+	for name := range sources {
+		queue = append(queue, name)
+	}
+	sort.Strings(queue)
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		for _, importPath := range parseImports(sources[name].Content) {
+			unitName := resolveImportPath(name, importPath)
+			if _, ok := sources[unitName]; ok {
+				continue
+			}
+
+			filename, ok := findImportFile(baseDir, includePaths, unitName)
+			if !ok {
+				// Let solc report the missing import, it knows best how to phrase it.
+				continue
+			}
+
+			source, err := readSource(unitName, filename)
+			if err != nil {
+				return nil, err
+			}
+
+			sources[unitName] = source
+			queue = append(queue, unitName)
+		}
+	}
+
+	return sources, nil
+}
+
+// findImportFile looks up the source unit name within the base directory and the include paths, returning the
+// first matching file. Source unit names escaping the searched directories are refused.
+func findImportFile(baseDir string, includePaths []string, unitName string) (string, bool) {
+	for _, dir := range append([]string{baseDir}, includePaths...) {
+		filename := filepath.Join(dir, filepath.FromSlash(unitName))
+		if !strings.HasPrefix(filename, dir+string(filepath.Separator)) {
+			continue
+		}
+
+		if info, err := os.Stat(filename); err == nil && !info.IsDir() {
+			return filename, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,69 @@
+package solc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectDirSources(t *testing.T) {
+	baseDir := t.TempDir()
+
+	files := map[string]string{
+		"contracts/Token.sol":                `import "@oz/token/ERC20.sol"; import "./utils/Math.sol"; contract Token {}`,
+		"contracts/utils/Math.sol":           `library Math {}`,
+		"node_modules/@oz/token/ERC20.sol":   `import "../utils/Context.sol"; contract ERC20 {}`,
+		"node_modules/@oz/utils/Context.sol": `abstract contract Context {}`,
+		"node_modules/@oz/token/Unused.sol":  `contract Unused {}`,
+		"lib/forge-std/src/Test.sol":         `contract Test {}`,
+		"contracts/README.md":                `# Not a source`,
+		"contracts/Commented.sol":            `// import "./Missing.sol";` + "\n" + `contract Commented {}`,
+		"contracts/MissingImport.sol":        `import "./DoesNotExist.sol"; contract MissingImport {}`,
+	}
+
+	for name, content := range files {
+		filename := filepath.Join(baseDir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
+		assert.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+	}
+
+	includePaths := getIncludePaths(baseDir)
+	assert.Equal(t, []string{filepath.Join(baseDir, "node_modules"), filepath.Join(baseDir, "lib")}, includePaths)
+
+	sources, err := collectDirSources(baseDir, includePaths, nil)
+	assert.NoError(t, err)
+
+	var names []string
+	for name := range sources {
+		names = append(names, name)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"contracts/Token.sol",
+		"contracts/utils/Math.sol",
+		"contracts/Commented.sol",
+		"contracts/MissingImport.sol",
+		"@oz/token/ERC20.sol",
+		"@oz/utils/Context.sol",
+	}, names)
+	assert.Equal(t, files["contracts/utils/Math.sol"], sources["contracts/utils/Math.sol"].Content)
+}
+
+func TestCompileDirErrors(t *testing.T) {
+	s := newTestSolc(t)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	_, err = s.CompileDir(context.TODO(), t.TempDir(), nil)
+	assert.Error(t, err)
+
+	_, err = s.CompileDir(context.TODO(), "/path/that/does/not/exist", config)
+	assert.Error(t, err)
+
+	_, err = s.CompileDir(context.TODO(), t.TempDir(), config)
+	assert.ErrorContains(t, err, "no solidity sources found")
+}
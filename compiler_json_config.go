@@ -1,6 +1,9 @@
 package solc
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 // Source represents the content of a Solidity source file.
 type Source struct {
@@ -9,16 +12,50 @@ type Source struct {
 
 // Settings defines the configuration settings for the Solidity compiler.
 type Settings struct {
-	Optimizer       Optimizer                      `json:"optimizer"`            // Configuration for the optimizer.
-	EVMVersion      string                         `json:"evmVersion,omitempty"` // The version of the Ethereum Virtual Machine to target. Optional.
-	Remappings      []string                       `json:"remappings,omitempty"` // List of remappings for library addresses. Optional.
-	OutputSelection map[string]map[string][]string `json:"outputSelection"`      // Specifies the type of information to output (e.g., ABI, AST).
+	Optimizer       Optimizer                      `json:"optimizer"`              // Configuration for the optimizer.
+	EVMVersion      string                         `json:"evmVersion,omitempty"`   // The version of the Ethereum Virtual Machine to target. Optional.
+	Remappings      []string                       `json:"remappings,omitempty"`   // List of remappings for library addresses. Optional.
+	OutputSelection map[string]map[string][]string `json:"outputSelection"`        // Specifies the type of information to output (e.g., ABI, AST).
+	ModelChecker    *ModelChecker                  `json:"modelChecker,omitempty"` // Configuration for the SMTChecker. Optional.
+
+	ParserErrorRecovery bool `json:"parserErrorRecovery,omitempty"` // Whether solc continues past recoverable parse errors.
+}
+
+// ModelChecker represents the configuration for the Solidity compiler's model checker (SMTChecker).
+type ModelChecker struct {
+	Engine       string              `json:"engine,omitempty"`       // The engine to use: "all", "bmc", "chc" or "none".
+	Targets      []string            `json:"targets,omitempty"`      // The verification targets (e.g., "assert", "overflow"). Optional.
+	Timeout      int                 `json:"timeout,omitempty"`      // The timeout for each SMT query in milliseconds. Optional.
+	Contracts    map[string][]string `json:"contracts,omitempty"`    // Map of source file names to the contracts to verify. Optional.
+	Solvers      []string            `json:"solvers,omitempty"`      // The SMT solvers to use (e.g., "z3", "cvc4", "smtlib2"). Optional.
+	ShowUnproved bool                `json:"showUnproved,omitempty"` // Indicates whether to report unproved targets.
 }
 
 // Optimizer represents the configuration for the Solidity compiler's optimizer.
 type Optimizer struct {
-	Enabled bool `json:"enabled"` // Indicates whether the optimizer is enabled.
-	Runs    int  `json:"runs"`    // Specifies the number of optimization runs.
+	Enabled bool              `json:"enabled"`           // Indicates whether the optimizer is enabled.
+	Runs    int               `json:"runs"`              // Specifies the number of optimization runs.
+	Details *OptimizerDetails `json:"details,omitempty"` // Fine-grained control of the optimizer components. Optional.
+}
+
+// OptimizerDetails switches the individual optimizer components on or off. Components left nil keep the solc
+// default, which depends on whether the optimizer is enabled.
+type OptimizerDetails struct {
+	Peephole          *bool       `json:"peephole,omitempty"`          // The peephole optimizer.
+	Inliner           *bool       `json:"inliner,omitempty"`           // The function inliner.
+	JumpdestRemover   *bool       `json:"jumpdestRemover,omitempty"`   // The unused jumpdest remover.
+	OrderLiterals     *bool       `json:"orderLiterals,omitempty"`     // The literal reordering in commutative operations.
+	Deduplicate       *bool       `json:"deduplicate,omitempty"`       // The duplicate code block remover.
+	Cse               *bool       `json:"cse,omitempty"`               // The common subexpression elimination.
+	ConstantOptimizer *bool       `json:"constantOptimizer,omitempty"` // The constant representation optimizer.
+	Yul               *bool       `json:"yul,omitempty"`               // The Yul optimizer.
+	YulDetails        *YulDetails `json:"yulDetails,omitempty"`        // Tuning options of the Yul optimizer.
+}
+
+// YulDetails represents the tuning options of the Yul optimizer.
+type YulDetails struct {
+	StackAllocation *bool  `json:"stackAllocation,omitempty"` // Improves the allocation of stack slots for variables.
+	OptimizerSteps  string `json:"optimizerSteps,omitempty"`  // The optimization step sequence, e.g. "dhfoDgvulfnTUtnIf".
 }
 
 // CompilerJsonConfig represents the JSON configuration for the Solidity compiler.
@@ -33,3 +70,100 @@ type CompilerJsonConfig struct {
 func (c *CompilerJsonConfig) ToJSON() ([]byte, error) {
 	return json.Marshal(c)
 }
+
+// DefaultOutputSelection returns the output selection commonly needed for deployment tooling. It selects the ABI,
+// metadata, creation and deployed bytecode, including the immutable references, for every contract in every source.
+func DefaultOutputSelection() map[string]map[string][]string {
+	return map[string]map[string][]string{
+		"*": {
+			"*": []string{
+				"abi",
+				"metadata",
+				"evm.bytecode",
+				"evm.deployedBytecode",
+				"evm.deployedBytecode.immutableReferences",
+			},
+		},
+	}
+}
+
+// withParserErrorRecovery returns the standard-json input with settings.parserErrorRecovery enabled, the standard-json
+// counterpart of the --error-recovery argument. The rest of the input is kept as is.
+func withParserErrorRecovery(input string) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(input), &fields); err != nil {
+		return "", err
+	}
+
+	settings := map[string]json.RawMessage{}
+	if raw, ok := fields["settings"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &settings); err != nil {
+			return "", err
+		}
+	}
+	settings["parserErrorRecovery"] = json.RawMessage("true")
+
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+	fields["settings"] = raw
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// normalizeJsonSources applies normalizeSource to the content of every source of the standard-json input, as well
+// as to a BOM leading the input itself. Sources provided through URLs are left for solc to read. The rest of the
+// input is kept as is.
+func normalizeJsonSources(input string) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(input, "\uFEFF")), &fields); err != nil {
+		return "", err
+	}
+
+	raw, ok := fields["sources"]
+	if !ok || string(raw) == "null" {
+		return strings.TrimPrefix(input, "\uFEFF"), nil
+	}
+
+	var sources map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &sources); err != nil {
+		return "", err
+	}
+
+	for _, source := range sources {
+		rawContent, ok := source["content"]
+		if !ok {
+			continue
+		}
+
+		var content string
+		if err := json.Unmarshal(rawContent, &content); err != nil {
+			return "", err
+		}
+
+		normalized, err := json.Marshal(normalizeSource(content))
+		if err != nil {
+			return "", err
+		}
+		source["content"] = normalized
+	}
+
+	raw, err := json.Marshal(sources)
+	if err != nil {
+		return "", err
+	}
+	fields["sources"] = raw
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
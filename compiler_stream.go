@@ -0,0 +1,298 @@
+package solc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CompileStream compiles the standard-json sources like Compile, but decodes the output of solc incrementally as
+// it's read from stdout rather than buffering it, calling fn with every contract result as soon as it's decoded.
+// It keeps the peak memory bounded for compilations producing tens of megabytes of output, e.g. of monorepos.
+//
+// Solc emits the errors after the contracts, so the streamed results carry neither errors nor model checker
+// diagnostics, and aren't flagged as imported. The errors and warnings are returned once the whole output is
+// decoded instead. An error returned by fn stops the compilation and is returned as is. Results of compilers
+// created from a reader carry no source hash, as the source is still being read when they're emitted.
+func (v *Compiler) CompileStream(fn func(*CompilerResult) error) ([]CompilationError, error) {
+	if fn == nil {
+		return nil, fmt.Errorf("callback must be provided to stream compilation results")
+	}
+
+	if v.config.JsonConfig == nil {
+		return nil, fmt.Errorf("streaming compilation requires a standard-json config")
+	}
+
+	hooks := v.solc.GetConfig().GetHooks()
+	hooks.compileStart(v.GetCompilerVersion())
+
+	started := time.Now()
+	errs, err := v.compileStream(fn)
+	hooks.compileEnd(v.GetCompilerVersion(), time.Since(started), err)
+
+	return errs, err
+}
+
+// compileStream runs solc and decodes its standard-json output as it's produced.
+func (v *Compiler) compileStream(fn func(*CompilerResult) error) ([]CompilationError, error) {
+	compilerVersion, binaryPath, args, err := v.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := v.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// The timeout carries its own cause, telling it apart from deadlines of the caller's context.
+	if maxDuration := v.config.GetMaxCompileDuration(); maxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, maxDuration, fmt.Errorf("%w: exceeded %s", ErrCompileTimeout, maxDuration))
+		defer cancel()
+	}
+
+	// The compilation is stopped early when the callback fails.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// #nosec G204
+	// The arguments are sanitized and verified by prepare, so we are safe to use them.
+	cmd := exec.CommandContext(ctx, binaryPath, args...)
+
+	var sourceHash string
+	var streamed *countingReader
+	if v.reader != nil {
+		if v.readerConsumed {
+			return nil, fmt.Errorf("source reader already consumed by a previous compilation")
+		}
+		v.readerConsumed = true
+
+		reader := v.reader
+		if maxBytes := v.config.GetMaxSourceBytes(); maxBytes > 0 {
+			reader = io.LimitReader(reader, maxBytes+1)
+		}
+		streamed = &countingReader{r: reader}
+		cmd.Stdin = streamed
+	} else {
+		input, err := v.input()
+		if err != nil {
+			return nil, err
+		}
+		sourceHash = HashSource(input)
+		cmd.Stdin = strings.NewReader(input)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// Standard-json output of solc doesn't report the version, so we ask the binary upfront.
+	binaryHash := v.binaryHash(compilerVersion, binaryPath)
+	version := v.binaryVersion(ctx, compilerVersion, binaryHash)
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	var callbackErr error
+	errs, decodeErr := decodeJsonStream(stdout, func(sourceKey string, name string, output jsonContractOutput) error {
+		result, err := v.resultFromJsonContract(compilerVersion, version, sourceKey, name, output)
+		if err != nil {
+			return err
+		}
+
+		result.SourceHash = sourceHash
+		result.CompilerBinaryHash = binaryHash
+
+		callbackErr = fn(result)
+		return callbackErr
+	})
+
+	if callbackErr != nil {
+		cancel()
+	} else if decodeErr != nil {
+		// Let solc run to completion so that its exit status tells whether the output is invalid or truncated.
+		_, _ = io.Copy(io.Discard, stdout)
+	}
+
+	err = cmd.Wait()
+	if callbackErr != nil {
+		return nil, callbackErr
+	}
+
+	if streamed != nil {
+		if sizeErr := v.config.checkSourceSize(streamed.n); sizeErr != nil {
+			return nil, sizeErr
+		}
+	}
+
+	if err != nil {
+		if cause := context.Cause(ctx); errors.Is(cause, ErrCompileTimeout) {
+			return nil, cause
+		}
+
+		if execErr := newCompileExecError(err, stderr.String()); execErr != nil {
+			err = execErr
+		}
+
+		v.solc.GetConfig().GetLogger().Error(
+			"Failed to compile Solidity sources",
+			zap.String("version", compilerVersion),
+			zap.String("stderr", stderr.String()),
+		)
+		return nil, err
+	}
+
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode solc output: %w", decodeErr)
+	}
+
+	for i := range errs {
+		errs[i].annotate()
+	}
+
+	if v.config.IsWarningsAsErrors() {
+		var warnings []CompilationError
+		for _, compilationError := range errs {
+			if compilationError.IsWarning() {
+				warnings = append(warnings, compilationError)
+			}
+		}
+
+		if len(warnings) > 0 {
+			return errs, fmt.Errorf("%w: %d warning(s), first: %s", ErrCompilationWarnings, len(warnings), warnings[0].Message)
+		}
+	}
+
+	return errs, nil
+}
+
+// decodeJsonStream decodes the standard-json output of solc from the reader, calling fn with the output of every
+// contract as soon as it's decoded, and returns the errors reported by solc. Other outputs, such as the ASTs of
+// the sources, are skipped without being buffered.
+func decodeJsonStream(r io.Reader, fn func(sourceKey string, name string, output jsonContractOutput) error) ([]CompilationError, error) {
+	dec := json.NewDecoder(r)
+	if err := expectJsonDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var errs []CompilationError
+	for dec.More() {
+		key, err := jsonKey(dec)
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "contracts":
+			err = decodeJsonObject(dec, func(sourceKey string) error {
+				return decodeJsonObject(dec, func(name string) error {
+					var output jsonContractOutput
+					if err := dec.Decode(&output); err != nil {
+						return err
+					}
+					return fn(sourceKey, name, output)
+				})
+			})
+		case "errors":
+			err = dec.Decode(&errs)
+		default:
+			err = skipJsonValue(dec)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := expectJsonDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	return errs, nil
+}
+
+// decodeJsonObject walks the members of the next JSON object, calling fn with the key of every member. The
+// callback is expected to consume the value of the member.
+func decodeJsonObject(dec *json.Decoder, fn func(key string) error) error {
+	if err := expectJsonDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		key, err := jsonKey(dec)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+
+	return expectJsonDelim(dec, '}')
+}
+
+// expectJsonDelim consumes the next token, which is expected to be the provided delimiter.
+func expectJsonDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("expected %q, found %v", delim, token)
+	}
+
+	return nil
+}
+
+// jsonKey consumes the next token, which is expected to be an object key.
+func jsonKey(dec *json.Decoder) (string, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+
+	key, ok := token.(string)
+	if !ok {
+		return "", fmt.Errorf("expected object key, found %v", token)
+	}
+
+	return key, nil
+}
+
+// skipJsonValue consumes the next value, token by token, without decoding it.
+func skipJsonValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
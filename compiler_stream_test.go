@@ -0,0 +1,132 @@
+package solc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileStream(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `if [ "$1" = "--version" ]; then echo "Version: 0.8.20+commit.a1b79de6.Linux.g++"; exit 0; fi
+cat > /dev/null
+echo '{"contracts": {"A.sol": {"A": {"abi": [], "evm": {"bytecode": {"object": "6080"}}}},'
+echo '"lib/B.sol": {"B": {"abi": [{"type": "constructor", "inputs": []}]}, "C": {"abi": []}}},'
+echo '"errors": [{"severity": "warning", "message": "Unused variable.", "sourceLocation": {"file": "A.sol", "start": 1, "end": 2}}],'
+echo '"sources": {"A.sol": {"id": 0, "ast": {"nodes": [{"nodes": [[], {"a": "}"}]}]}}}}'`)
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "A", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+
+	compiler, err := NewCompiler(context.TODO(), s, config, `{"language": "Solidity"}`)
+	assert.NoError(t, err)
+
+	var streamed []*CompilerResult
+	errs, err := compiler.CompileStream(func(result *CompilerResult) error {
+		streamed = append(streamed, result)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	if assert.Len(t, streamed, 3) {
+		assert.Equal(t, "A.sol", streamed[0].GetSourcePath())
+		assert.Equal(t, "A", streamed[0].ContractName)
+		assert.Equal(t, "6080", streamed[0].GetBytecode())
+		assert.True(t, streamed[0].IsEntry())
+		assert.Equal(t, "0.8.20+commit.a1b79de6.Linux.g++", streamed[0].CompilerVersion)
+		assert.Equal(t, HashSource(`{"language": "Solidity"}`), streamed[0].GetSourceHash())
+		assert.NotEmpty(t, streamed[0].GetCompilerBinaryHash())
+		assert.Empty(t, streamed[0].GetErrors())
+
+		assert.Equal(t, "B", streamed[1].ContractName)
+		assert.Equal(t, `[{"inputs":[],"type":"constructor"}]`, streamed[1].GetABI())
+		assert.False(t, streamed[1].IsEntry())
+		assert.Equal(t, "C", streamed[2].ContractName)
+	}
+
+	if assert.Len(t, errs, 1) {
+		assert.True(t, errs[0].IsWarning())
+		assert.Equal(t, "A.sol", errs[0].SourceLocation.File)
+	}
+
+	// Warnings as errors are reported once the whole output is decoded.
+	config.SetWarningsAsErrors(true)
+	errs, err = compiler.CompileStream(func(*CompilerResult) error { return nil })
+	assert.ErrorIs(t, err, ErrCompilationWarnings)
+	assert.Len(t, errs, 1)
+	config.SetWarningsAsErrors(false)
+
+	// A failing callback stops the compilation.
+	stop := errors.New("stop")
+	calls := 0
+	_, err = compiler.CompileStream(func(*CompilerResult) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+
+	_, err = compiler.CompileStream(nil)
+	assert.Error(t, err)
+
+	simpleConfig, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+	simpleCompiler, err := NewCompiler(context.TODO(), s, simpleConfig, "contract A {}")
+	assert.NoError(t, err)
+	_, err = simpleCompiler.CompileStream(func(*CompilerResult) error { return nil })
+	assert.ErrorContains(t, err, "requires a standard-json config")
+}
+
+func TestCompileStreamIncremental(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	marker := filepath.Join(t.TempDir(), "received")
+
+	// The second contract is only emitted once the first one was received, which deadlocks unless the output is
+	// decoded as it's produced.
+	installFakeBinary(t, s, "0.8.20", `if [ "$1" = "--version" ]; then exit 1; fi
+cat > /dev/null
+echo '{"contracts": {"A.sol": {"A": {"abi": []},'
+while [ ! -f `+marker+` ]; do sleep 0.05; done
+echo '"B": {"abi": []}}}}'`)
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "A", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+	config.SetMaxCompileDuration(5 * time.Second)
+
+	compiler, err := NewCompiler(context.TODO(), s, config, `{"language": "Solidity"}`)
+	assert.NoError(t, err)
+
+	var names []string
+	_, err = compiler.CompileStream(func(result *CompilerResult) error {
+		names = append(names, result.ContractName)
+		return os.WriteFile(marker, nil, 0600)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, names)
+}
+
+func TestCompileStreamFailures(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	config, err := NewCompilerConfigFromJSON("0.8.20", "A", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+
+	compiler, err := NewCompiler(context.TODO(), s, config, `{"language": "Solidity"}`)
+	assert.NoError(t, err)
+
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"A.sol": {"A": {"abi": []}}}, "errors": ['; echo "boom" >&2; exit 3`)
+	_, err = compiler.CompileStream(func(*CompilerResult) error { return nil })
+	var execErr *CompileExecError
+	if assert.ErrorAs(t, err, &execErr) {
+		assert.Equal(t, 3, execErr.ExitCode)
+		assert.Contains(t, execErr.Stderr, "boom")
+	}
+
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": ["not an object"]}'`)
+	_, err = compiler.CompileStream(func(*CompilerResult) error { return nil })
+	assert.ErrorContains(t, err, "failed to decode solc output")
+}
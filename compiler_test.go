@@ -1,10 +1,21 @@
 package solc
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -18,9 +29,7 @@ func TestCompiler(t *testing.T) {
 	// Replace the global logger.
 	zap.ReplaceGlobals(logger)
 
-	solcConfig, err := NewDefaultConfig()
-	assert.NoError(t, err)
-	assert.NotNil(t, solcConfig)
+	solcConfig := newTestConfig(t)
 
 	solc, err := New(context.TODO(), solcConfig)
 	assert.NoError(t, err)
@@ -193,8 +202,8 @@ func TestCompiler(t *testing.T) {
 				return
 			}
 
-			assert.NoError(t, err)
-			assert.NotNil(t, compiler)
+			require.NoError(t, err)
+			require.NotNil(t, compiler)
 			assert.NotNil(t, compiler.GetContext())
 			assert.NotNil(t, compiler.GetSources())
 
@@ -212,6 +221,11 @@ func TestCompiler(t *testing.T) {
 
 			compilerResults, err := compiler.Compile()
 			if testCase.wantCompileErr {
+				require.Error(t, err)
+				if compilerResults == nil {
+					return
+				}
+
 				for _, result := range compilerResults.GetResults() {
 					assert.True(t, result.HasErrors())
 					assert.GreaterOrEqual(t, len(result.GetErrors()), 1)
@@ -220,8 +234,8 @@ func TestCompiler(t *testing.T) {
 				return
 			}
 
-			assert.NoError(t, err)
-			assert.NotNil(t, compilerResults)
+			require.NoError(t, err)
+			require.NotNil(t, compilerResults)
 
 			for _, result := range compilerResults.GetResults() {
 				assert.NotEmpty(t, result.GetRequestedVersion())
@@ -243,9 +257,7 @@ func TestCompilerFromSolc(t *testing.T) {
 
 	zap.ReplaceGlobals(logger)
 
-	solcConfig, err := NewDefaultConfig()
-	assert.NoError(t, err)
-	assert.NotNil(t, solcConfig)
+	solcConfig := newTestConfig(t)
 
 	solc, err := New(context.TODO(), solcConfig)
 	assert.NoError(t, err)
@@ -416,8 +428,8 @@ func TestCompilerFromSolc(t *testing.T) {
 				return
 			}
 
-			assert.NoError(t, err)
-			assert.NotNil(t, compilerResults)
+			require.NoError(t, err)
+			require.NotNil(t, compilerResults)
 
 			for _, result := range compilerResults.GetResults() {
 				assert.NotEmpty(t, result.GetRequestedVersion())
@@ -440,9 +452,7 @@ func TestCompilerWithJSON(t *testing.T) {
 	// Replace the global logger.
 	zap.ReplaceGlobals(logger)
 
-	solcConfig, err := NewDefaultConfig()
-	assert.NoError(t, err)
-	assert.NotNil(t, solcConfig)
+	solcConfig := newTestConfig(t)
 
 	solc, err := New(context.TODO(), solcConfig)
 	assert.NoError(t, err)
@@ -522,8 +532,8 @@ func TestCompilerWithJSON(t *testing.T) {
 				return
 			}
 
-			assert.NoError(t, err)
-			assert.NotNil(t, compiler)
+			require.NoError(t, err)
+			require.NotNil(t, compiler)
 			assert.NotNil(t, compiler.GetContext())
 			assert.NotNil(t, compiler.GetSources())
 
@@ -541,6 +551,11 @@ func TestCompilerWithJSON(t *testing.T) {
 
 			compilerResults, err := compiler.Compile()
 			if testCase.wantCompileErr {
+				require.Error(t, err)
+				if compilerResults == nil {
+					return
+				}
+
 				for _, result := range compilerResults.GetResults() {
 					assert.True(t, result.HasErrors())
 					assert.GreaterOrEqual(t, len(result.GetErrors()), 1)
@@ -549,8 +564,8 @@ func TestCompilerWithJSON(t *testing.T) {
 				return
 			}
 
-			assert.NoError(t, err)
-			assert.NotNil(t, compilerResults)
+			require.NoError(t, err)
+			require.NotNil(t, compilerResults)
 			assert.NotNil(t, compilerResults.GetResults())
 			assert.NotNil(t, compilerResults.GetEntryContract())
 
@@ -567,3 +582,1207 @@ func TestCompilerWithJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestResultsFromJsonModelChecker(t *testing.T) {
+	compiler := &Compiler{
+		ctx:    context.TODO(),
+		config: &CompilerConfig{EntrySourceName: "Checked"},
+	}
+
+	output := `{
+		"contracts": {
+			"Checked.sol": {"Checked": {"abi": [], "evm": {"bytecode": {"object": "6080"}}}},
+			"Other.sol": {"Other": {"abi": [], "evm": {"bytecode": {"object": "6080"}}}}
+		},
+		"errors": [
+			{"component": "general", "errorCode": "6328", "message": "CHC: Assertion violation happens here.", "severity": "warning", "type": "Warning", "sourceLocation": {"file": "Checked.sol", "start": 10, "end": 20}},
+			{"component": "general", "message": "Unused local variable.", "severity": "warning", "type": "Warning", "sourceLocation": {"file": "Checked.sol", "start": 30, "end": 40}}
+		],
+		"version": "0.8.20+commit.a1b79de6"
+	}`
+
+	results, err := compiler.resultsFromJson(context.TODO(), "0.8.20", "", *bytes.NewBufferString(output))
+	assert.NoError(t, err)
+	assert.NotNil(t, results)
+
+	for _, result := range results.GetResults() {
+		switch result.GetContractName() {
+		case "Checked":
+			diagnostics := result.GetModelCheckerDiagnostics()
+			assert.Len(t, diagnostics, 1)
+			assert.Equal(t, "6328", diagnostics[0].ErrorCode)
+			assert.True(t, diagnostics[0].IsModelCheckerDiagnostic())
+		case "Other":
+			assert.Empty(t, result.GetModelCheckerDiagnostics())
+		}
+	}
+}
+
+func TestResultsFromJsonImmutableReferences(t *testing.T) {
+	compiler := &Compiler{
+		ctx:    context.TODO(),
+		config: &CompilerConfig{EntrySourceName: "Immutable"},
+	}
+
+	output := `{
+		"contracts": {
+			"Immutable.sol": {"Immutable": {"abi": [], "evm": {
+				"bytecode": {"object": "6080"},
+				"deployedBytecode": {"object": "6080", "immutableReferences": {"3": [{"start": 77, "length": 32}, {"start": 120, "length": 32}]}}
+			}}}
+		},
+		"version": "0.8.20+commit.a1b79de6"
+	}`
+
+	results, err := compiler.resultsFromJson(context.TODO(), "0.8.20", "", *bytes.NewBufferString(output))
+	assert.NoError(t, err)
+
+	entry := results.GetEntryContract()
+	assert.NotNil(t, entry)
+	assert.Equal(t, map[string][]ImmutableReference{
+		"3": {{Start: 77, Length: 32}, {Start: 120, Length: 32}},
+	}, entry.GetImmutableReferences())
+
+	selection := DefaultOutputSelection()
+	assert.Contains(t, selection["*"]["*"], "evm.deployedBytecode.immutableReferences")
+}
+
+func TestOptimizerDetailsToJSON(t *testing.T) {
+	disabled := false
+	jsonConfig := &CompilerJsonConfig{
+		Language: "Solidity",
+		Settings: Settings{
+			Optimizer: Optimizer{
+				Enabled: true,
+				Runs:    200,
+				Details: &OptimizerDetails{
+					Peephole:   &disabled,
+					YulDetails: &YulDetails{OptimizerSteps: "dhfoDgvulfnTUtnIf"},
+				},
+			},
+		},
+	}
+
+	data, err := jsonConfig.ToJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"optimizer":{"enabled":true,"runs":200,"details":{"peephole":false,"yulDetails":{"optimizerSteps":"dhfoDgvulfnTUtnIf"}}}`)
+
+	jsonConfig.Settings.Optimizer.Details = nil
+	data, err = jsonConfig.ToJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"optimizer":{"enabled":true,"runs":200}`)
+}
+
+func TestStackTooDeepSuggestion(t *testing.T) {
+	compiler := &Compiler{
+		ctx:    context.TODO(),
+		config: &CompilerConfig{EntrySourceName: "Deep"},
+	}
+
+	output := `{
+		"contracts": {},
+		"errors": [
+			{"component": "general", "message": "Stack too deep. Try compiling with ` + "`--via-ir`" + `.", "severity": "error", "type": "CompilerError"},
+			{"component": "general", "message": "Unused local variable.", "severity": "warning", "type": "Warning"}
+		],
+		"version": "0.8.20+commit.a1b79de6"
+	}`
+
+	results, err := compiler.resultsFromJson(context.TODO(), "0.8.20", "", *bytes.NewBufferString(output))
+	assert.NoError(t, err)
+	assert.Len(t, results.GetResults(), 1)
+
+	errors := results.GetResults()[0].GetErrors()
+	assert.Len(t, errors, 2)
+	assert.True(t, errors[0].IsStackTooDeep())
+	assert.Equal(t, SuggestionEnableViaIR, errors[0].Suggestion)
+	assert.False(t, errors[1].IsStackTooDeep())
+	assert.Empty(t, errors[1].Suggestion)
+}
+
+func TestCompileStackTooDeepFailure(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `echo "CompilerError: Stack too deep. Try compiling with --via-ir." >&2; exit 1`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	compiler, err := NewCompiler(context.TODO(), s, config, "contract A {}")
+	assert.NoError(t, err)
+
+	results, err := compiler.Compile()
+	assert.ErrorIs(t, err, ErrStackTooDeep)
+	assert.NotNil(t, results)
+	assert.Len(t, results.GetResults(), 1)
+	assert.Equal(t, SuggestionEnableViaIR, results.GetResults()[0].GetErrors()[0].Suggestion)
+}
+
+func TestCompileTimeout(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `exec sleep 5`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+	config.SetMaxCompileDuration(100 * time.Millisecond)
+	assert.Equal(t, 100*time.Millisecond, config.GetMaxCompileDuration())
+
+	compiler, err := NewCompiler(context.TODO(), s, config, "contract A {}")
+	assert.NoError(t, err)
+
+	started := time.Now()
+	results, err := compiler.Compile()
+	assert.ErrorIs(t, err, ErrCompileTimeout)
+	assert.Nil(t, results)
+	assert.Less(t, time.Since(started), 5*time.Second)
+
+	// A deadline of the caller's context isn't reported as the compile timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	config.SetMaxCompileDuration(time.Minute)
+
+	compiler, err = NewCompiler(ctx, s, config, "contract A {}")
+	assert.NoError(t, err)
+
+	_, err = compiler.Compile()
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrCompileTimeout)
+}
+
+func TestCompileDuration(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `sleep 0.1; echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "0.8.20+commit.a1b79de6"}'`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, results.GetDuration(), 100*time.Millisecond)
+}
+
+func TestCompileSourceHashes(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"A.sol": {"A": {"abi": [], "evm": {"bytecode": {"object": "6080"}}}}}, "version": "0.8.20+commit.a1b79de6"}'`)
+
+	jsonConfig := &CompilerJsonConfig{
+		Language: "Solidity",
+		Sources: map[string]Source{
+			"A.sol": {Content: "contract A {}"},
+			"B.sol": {Content: "contract B {}"},
+		},
+		Settings: Settings{OutputSelection: DefaultOutputSelection()},
+	}
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "A", jsonConfig)
+	assert.NoError(t, err)
+
+	input, err := jsonConfig.ToJSON()
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), string(input), config)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"A.sol": HashSource("contract A {}"),
+		"B.sol": HashSource("contract B {}"),
+	}, results.GetSourceHashes())
+}
+
+func TestCompileErrorRecovery(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `
+for arg in "$@"; do
+	if [ "$arg" = "--error-recovery" ]; then
+		echo '{"contracts": {"<stdin>:A": {"bin": "", "abi": []}}, "version": "0.8.20+commit.a1b79de6"}'
+	fi
+done
+echo "ParserError: Expected ';' but got '}'" >&2
+exit 1`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	compiler, err := NewCompiler(context.TODO(), s, config, "contract A { uint a }")
+	assert.NoError(t, err)
+
+	results, err := compiler.Compile()
+	assert.Error(t, err)
+	assert.False(t, results.IsPartial())
+	assert.Len(t, results.GetResults(), 1)
+
+	config.SetErrorRecovery(true)
+	assert.True(t, config.IsErrorRecovery())
+	assert.Contains(t, config.GetCompileArguments(), "--error-recovery")
+	assert.NotContains(t, config.GetArguments(), "--error-recovery")
+
+	results, err = compiler.Compile()
+	assert.Error(t, err)
+	assert.True(t, results.IsPartial())
+	assert.Len(t, results.GetResults(), 2)
+	assert.Equal(t, "A", results.GetResults()[0].GetContractName())
+	assert.True(t, results.GetResults()[0].HasErrors())
+
+	// The partial results reach the callers of Solc.Compile too.
+	results, err = s.Compile(context.TODO(), "contract A { uint a }", config)
+	assert.Error(t, err)
+	if assert.NotNil(t, results) {
+		assert.True(t, results.IsPartial())
+		assert.Equal(t, "A", results.GetResults()[0].GetContractName())
+	}
+
+	config.SetErrorRecovery(false)
+	results, err = s.Compile(context.TODO(), "contract A { uint a }", config)
+	assert.Error(t, err)
+	assert.Nil(t, results)
+}
+
+func TestCompileJsonErrorRecovery(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `
+for arg in "$@"; do
+	if [ "$arg" = "--error-recovery" ]; then
+		echo "unexpected --error-recovery argument" >&2
+		exit 1
+	fi
+done
+if grep -q '"parserErrorRecovery":true'; then
+	echo '{"contracts": {"A.sol": {"A": {"abi": []}}}, "errors": [{"severity": "error", "type": "ParserError", "message": "Expected semicolon."}], "version": "0.8.20+commit.a1b79de6"}'
+else
+	echo '{"errors": [{"severity": "error", "type": "ParserError", "message": "Expected semicolon."}], "version": "0.8.20+commit.a1b79de6"}'
+fi`)
+
+	jsonConfig := &CompilerJsonConfig{
+		Language: "Solidity",
+		Sources:  map[string]Source{"A.sol": {Content: "contract A { uint a }"}},
+		Settings: Settings{OutputSelection: DefaultOutputSelection()},
+	}
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "A", jsonConfig)
+	assert.NoError(t, err)
+
+	config.SetErrorRecovery(true)
+	assert.NotContains(t, config.GetCompileArguments(), "--error-recovery")
+
+	input, err := jsonConfig.ToJSON()
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), string(input), config)
+	assert.NoError(t, err)
+	if assert.Len(t, results.GetResults(), 2) {
+		assert.Equal(t, "A", results.GetResults()[0].GetContractName())
+		assert.True(t, results.GetResults()[1].HasErrors())
+	}
+
+	// Settings of the input other than the error recovery are kept.
+	recovered, err := withParserErrorRecovery(string(input))
+	assert.NoError(t, err)
+
+	var decoded CompilerJsonConfig
+	assert.NoError(t, json.Unmarshal([]byte(recovered), &decoded))
+	assert.True(t, decoded.Settings.ParserErrorRecovery)
+	assert.Equal(t, jsonConfig.Sources, decoded.Sources)
+	assert.Equal(t, jsonConfig.Settings.OutputSelection, decoded.Settings.OutputSelection)
+}
+
+func TestCompileLegacyCombinedJson(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.4.26"})
+	installFakeBinary(t, s, "0.4.26", `
+if [ "$1" = "--version" ]; then
+	echo "solc, the solidity compiler commandline interface"
+	echo "Version: 0.4.26+commit.4563c3fc.Linux.g++"
+	exit 0
+fi
+echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": "[{\"type\":\"fallback\"}]"}}, "version": {"unexpected": true}}'`)
+
+	config, err := NewDefaultCompilerConfig("0.4.26")
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	assert.Len(t, results.GetResults(), 1)
+
+	result := results.GetResults()[0]
+	assert.Equal(t, "0.4.26+commit.4563c3fc.Linux.g++", result.GetCompilerVersion())
+	assert.Equal(t, `[{"type":"fallback"}]`, result.GetABI())
+}
+
+func TestCombinedJsonVersion(t *testing.T) {
+	assert.Equal(t, "0.8.20+commit.a1b79de6", combinedJsonVersion([]byte(`"0.8.20+commit.a1b79de6"`)))
+	assert.Equal(t, "", combinedJsonVersion(nil))
+	assert.Equal(t, "", combinedJsonVersion([]byte(`{"version": "0.4.26"}`)))
+}
+
+func TestCompilerValidate(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `echo "solc must not be run" >&2; exit 1`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	compiler, err := NewCompiler(context.TODO(), s, config, "contract A {}")
+	assert.NoError(t, err)
+	assert.NoError(t, compiler.Validate())
+
+	compiler.SetCompilerVersion("0.8.21")
+	assert.Error(t, compiler.Validate(), "binary is not installed")
+
+	jsonConfig, err := NewCompilerConfigFromJSON("0.8.20", "A", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+
+	compiler, err = NewCompiler(context.TODO(), s, jsonConfig, `{"language": "Solidity"}`)
+	assert.NoError(t, err)
+	assert.NoError(t, compiler.Validate())
+
+	compiler, err = NewCompiler(context.TODO(), s, jsonConfig, "contract A {}")
+	assert.NoError(t, err)
+	assert.ErrorContains(t, compiler.Validate(), "standard-json input")
+
+	jsonConfig.SetArguments([]string{"--combined-json", "bin"})
+	compiler, err = NewCompiler(context.TODO(), s, jsonConfig, `{"language": "Solidity"}`)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, compiler.Validate(), "--standard-json")
+}
+
+func TestCompileWithCustomBinaryPath(t *testing.T) {
+	s := newTestSolc(t)
+
+	binaryPath := filepath.Join(t.TempDir(), "solc-debug")
+	assert.NoError(t, os.WriteFile(binaryPath, []byte(`#!/bin/sh
+echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "0.8.26-develop"}'
+`), 0700)) // #nosec G306
+
+	config, err := NewDefaultCompilerConfig("0.8.26")
+	assert.NoError(t, err)
+
+	// The version isn't a known release so it can't be resolved without the custom binary.
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.Error(t, err)
+
+	config.SetBinaryPath(binaryPath)
+	assert.Equal(t, binaryPath, config.GetBinaryPath())
+
+	results, err := s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8.26-develop", results.GetResults()[0].GetCompilerVersion())
+	assert.Equal(t, "0.8.26", results.GetResults()[0].GetRequestedVersion())
+
+	config.SetBinaryPath(filepath.Dir(binaryPath))
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.ErrorContains(t, err, "directory")
+
+	config.SetBinaryPath(binaryPath + "-missing")
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestCompileSourceHash(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}, "<stdin>:B": {"bin": "6080", "abi": []}}}'`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	source := "contract A {} contract B {}"
+	results, err := s.Compile(context.TODO(), source, config)
+	assert.NoError(t, err)
+	assert.Len(t, results.GetResults(), 2)
+	for _, result := range results.GetResults() {
+		assert.Equal(t, HashSource(source), result.GetSourceHash())
+	}
+
+	installFakeBinary(t, s, "0.8.20", `echo "ParserError" >&2; exit 1`)
+	compiler, err := NewCompiler(context.TODO(), s, config, source)
+	assert.NoError(t, err)
+
+	results, err = compiler.Compile()
+	assert.Error(t, err)
+	assert.Equal(t, HashSource(source), results.GetResults()[0].GetSourceHash())
+}
+
+func TestCompileLatestVersion(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"}, Version{TagName: "v0.8.19"})
+	installFakeBinary(t, s, "0.8.20", `echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "0.8.20+commit.a1b79de6"}'`)
+
+	config, err := NewDefaultCompilerConfig(VersionLatest)
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8.20", results.GetResults()[0].GetRequestedVersion())
+	assert.Equal(t, "0.8.20+commit.a1b79de6", results.GetResults()[0].GetCompilerVersion())
+	assert.Equal(t, VersionLatest, config.GetCompilerVersion())
+}
+
+func TestCompileJsonCompilerVersion(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `
+if [ "$1" = "--version" ]; then
+	echo "Version: 0.8.20+commit.a1b79de6.Linux.g++"
+	exit 0
+fi
+cat > /dev/null
+echo '{"contracts": {"A.sol": {"A": {"abi": [], "evm": {"bytecode": {"object": "6080"}}}}}}'`)
+
+	jsonConfig := &CompilerJsonConfig{
+		Language: "Solidity",
+		Sources:  map[string]Source{"A.sol": {Content: "contract A {}"}},
+		Settings: Settings{OutputSelection: DefaultOutputSelection()},
+	}
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "A", jsonConfig)
+	assert.NoError(t, err)
+
+	input, err := jsonConfig.ToJSON()
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), string(input), config)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, results.GetResults()[0].GetCompilerVersion())
+	assert.Equal(t, "0.8.20+commit.a1b79de6.Linux.g++", results.GetResults()[0].GetCompilerVersion())
+
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"A.sol": {"A": {"abi": []}}}, "version": "0.8.20+commit.a1b79de6"}'`)
+
+	results, err = s.Compile(context.TODO(), string(input), config)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8.20+commit.a1b79de6", results.GetResults()[0].GetCompilerVersion())
+}
+
+func TestCompileJsonCompilerVersionCached(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	calls := filepath.Join(t.TempDir(), "calls")
+	installFakeBinary(t, s, "0.8.20", `
+if [ "$1" = "--version" ]; then
+	echo version >> `+calls+`
+	echo "Version: 0.8.20+commit.a1b79de6.Linux.g++"
+	exit 0
+fi
+cat > /dev/null
+echo '{"contracts": {"A.sol": {"A": {"abi": []}}}}'`)
+
+	jsonConfig := &CompilerJsonConfig{Language: "Solidity", Settings: Settings{OutputSelection: DefaultOutputSelection()}}
+	config, err := NewCompilerConfigFromJSON("0.8.20", "A", jsonConfig)
+	assert.NoError(t, err)
+
+	input, err := jsonConfig.ToJSON()
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		results, err := s.Compile(context.TODO(), string(input), config)
+		assert.NoError(t, err)
+		assert.Equal(t, "0.8.20+commit.a1b79de6.Linux.g++", results.GetResults()[0].GetCompilerVersion())
+	}
+
+	data, err := os.ReadFile(calls)
+	assert.NoError(t, err)
+	assert.Equal(t, "version\n", string(data), "the binary is asked for its version once")
+}
+
+func TestCompileJsonErrorsDeduplicated(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; cat <<'EOF'
+{
+	"contracts": {
+		"A.sol": {"A": {"abi": []}, "A2": {"abi": []}},
+		"B.sol": {"B": {"abi": []}}
+	},
+	"errors": [
+		{"severity": "warning", "message": "Unused variable.", "sourceLocation": {"file": "A.sol", "start": 1, "end": 2}},
+		{"severity": "warning", "message": "Unreachable code.", "sourceLocation": {"file": "C.sol", "start": 1, "end": 2}},
+		{"severity": "warning", "message": "Global warning."}
+	],
+	"version": "0.8.20+commit.a1b79de6"
+}
+EOF`)
+
+	jsonConfig := &CompilerJsonConfig{Language: "Solidity", Settings: Settings{OutputSelection: DefaultOutputSelection()}}
+	config, err := NewCompilerConfigFromJSON("0.8.20", "A", jsonConfig)
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), `{"language": "Solidity"}`, config)
+	assert.NoError(t, err)
+	assert.Len(t, results.GetResults(), 4)
+
+	messages := func(errors []CompilationError) []string {
+		var msgs []string
+		for _, err := range errors {
+			msgs = append(msgs, err.Message)
+		}
+		return msgs
+	}
+
+	for _, result := range results.GetResults() {
+		switch result.GetContractName() {
+		case "A", "A2":
+			assert.Equal(t, []string{"Unused variable."}, messages(result.GetErrors()))
+		case "B":
+			assert.Empty(t, result.GetErrors())
+		default:
+			assert.Equal(t, []string{"Unreachable code.", "Global warning."}, messages(result.GetErrors()))
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"Unused variable.", "Unreachable code.", "Global warning."}, messages(results.AllErrors()))
+}
+
+func TestCompileWithContext(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `sleep 0.3; echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "0.8.20"}'`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	compiler, err := NewCompiler(context.Background(), s, config, "contract A {}")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = compiler.CompileWithContext(ctx)
+	assert.Error(t, err)
+
+	results, err := compiler.CompileWithContext(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, results.GetResults(), 1)
+}
+
+func TestNewCompilerFromReader(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "0.8.20"}'`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	_, err = NewCompilerFromReader(context.TODO(), s, config, nil)
+	assert.Error(t, err)
+
+	_, err = NewCompilerFromReader(context.TODO(), nil, config, strings.NewReader("contract A {}"))
+	assert.Error(t, err)
+
+	source := strings.Repeat("// generated\n", 10000) + "contract A {}"
+	compiler, err := NewCompilerFromReader(context.TODO(), s, config, strings.NewReader(source))
+	assert.NoError(t, err)
+	assert.Empty(t, compiler.GetSources())
+
+	results, err := compiler.Compile()
+	assert.NoError(t, err)
+	assert.Equal(t, "A", results.GetResults()[0].GetContractName())
+	assert.Equal(t, HashSource(source), results.GetResults()[0].GetSourceHash())
+
+	_, err = compiler.Compile()
+	assert.ErrorContains(t, err, "already consumed")
+}
+
+func TestAsArtifactMap(t *testing.T) {
+	results := &CompilerResults{Results: []*CompilerResult{
+		{ContractName: "Token", SourcePath: "contracts/Token.sol"},
+		{ContractName: "Ownable", SourcePath: "contracts/access/Ownable.sol"},
+		{ContractName: "Ownable", SourcePath: "lib/oz/Ownable.sol"},
+		{Errors: []CompilationError{{Message: "Global warning."}}},
+	}}
+
+	artifacts := results.AsArtifactMap()
+	assert.Len(t, artifacts, 3)
+	assert.Same(t, results.Results[0], artifacts["Token"])
+	assert.Same(t, results.Results[1], artifacts["contracts/access/Ownable.sol:Ownable"])
+	assert.Same(t, results.Results[2], artifacts["lib/oz/Ownable.sol:Ownable"])
+
+	var nilResults *CompilerResults
+	assert.Nil(t, nilResults.AsArtifactMap())
+}
+
+func TestDeployableContracts(t *testing.T) {
+	results := &CompilerResults{Results: []*CompilerResult{
+		{ContractName: "Token", Bytecode: "6080"},
+		{ContractName: "IToken"},
+		{ContractName: "Math", Bytecode: "0x6080"},
+		{ContractName: "Abstract", Bytecode: "0x"},
+		{Errors: []CompilationError{{Message: "Global warning."}}},
+	}}
+
+	assert.Equal(t, []*CompilerResult{results.Results[0], results.Results[2]}, results.DeployableContracts())
+
+	var nilResults *CompilerResults
+	assert.Nil(t, nilResults.DeployableContracts())
+}
+
+func TestDiagnosticsBySource(t *testing.T) {
+	late := CompilationError{Message: "Unused variable.", Severity: "warning", SourceLocation: CompilationErrorSourceLocation{File: "A.sol", Start: 120, End: 130}}
+	early := CompilationError{Message: "Undeclared identifier.", Severity: "error", SourceLocation: CompilationErrorSourceLocation{File: "A.sol", Start: 10, End: 20}}
+	imported := CompilationError{Message: "Shadowing.", Severity: "warning", SourceLocation: CompilationErrorSourceLocation{File: "lib/B.sol", Start: 5, End: 8}}
+	global := CompilationError{Message: "Contract code size exceeds the limit.", Severity: "warning"}
+
+	results := &CompilerResults{Results: []*CompilerResult{
+		{ContractName: "A", Errors: []CompilationError{late, imported, early}},
+		{ContractName: "B", Errors: []CompilationError{imported, global}},
+	}}
+
+	assert.Equal(t, map[string][]CompilationError{
+		"A.sol":     {early, late},
+		"lib/B.sol": {imported},
+		"":          {global},
+	}, results.DiagnosticsBySource())
+
+	var nilResults *CompilerResults
+	assert.Empty(t, nilResults.DiagnosticsBySource())
+}
+
+func TestSplitContractKey(t *testing.T) {
+	source, name := splitContractKey("<stdin>:dnsResolver")
+	assert.Equal(t, "<stdin>", source)
+	assert.Equal(t, "dnsResolver", name)
+
+	source, name = splitContractKey(`C:\contracts\A.sol:A`)
+	assert.Equal(t, `C:\contracts\A.sol`, source)
+	assert.Equal(t, "A", name)
+
+	source, name = splitContractKey("A")
+	assert.Equal(t, "", source)
+	assert.Equal(t, "A", name)
+}
+
+func TestCompilerUseVersion(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"}, Version{TagName: "v0.8.19"})
+	installFakeBinary(t, s, "0.8.20", `exit 1`)
+
+	config, err := NewDefaultCompilerConfig("0.8.19")
+	assert.NoError(t, err)
+
+	compiler, err := NewCompiler(context.TODO(), s, config, "contract A {}")
+	assert.NoError(t, err)
+
+	assert.ErrorContains(t, compiler.UseVersion("0.8.2O"), "not a known release")
+	assert.ErrorContains(t, compiler.UseVersion("0.8.19"), "not installed")
+	assert.Equal(t, "0.8.19", compiler.GetCompilerVersion())
+
+	assert.NoError(t, compiler.UseVersion("v0.8.20"))
+	assert.Equal(t, "0.8.20", compiler.GetCompilerVersion())
+	assert.NoError(t, compiler.Validate())
+
+	assert.NoError(t, compiler.UseVersion(VersionLatest))
+	assert.Equal(t, VersionLatest, compiler.GetCompilerVersion())
+}
+
+func TestCompilerCheckVersionPragma(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.7.6"})
+	installFakeBinary(t, s, "0.7.6", `echo "solc must not be run" >&2; exit 1`)
+
+	config, err := NewDefaultCompilerConfig("0.7.6")
+	assert.NoError(t, err)
+
+	source := "pragma solidity ^0.8.0;\ncontract A {}"
+
+	compiler, err := NewCompiler(context.TODO(), s, config, source)
+	assert.NoError(t, err)
+	assert.NoError(t, compiler.Validate(), "the check is disabled by default")
+
+	config.SetCheckVersionPragma(true)
+	err = compiler.Validate()
+	assert.ErrorIs(t, err, ErrVersionPragmaMismatch)
+	assert.ErrorContains(t, err, "requires solidity ^0.8.0, compiler version is 0.7.6")
+
+	_, err = compiler.Compile()
+	assert.ErrorIs(t, err, ErrVersionPragmaMismatch)
+
+	compiler, err = NewCompiler(context.TODO(), s, config, "pragma solidity >=0.7.0 <0.9.0;\ncontract A {}")
+	assert.NoError(t, err)
+	assert.NoError(t, compiler.Validate())
+
+	jsonConfig, err := NewCompilerConfigFromJSON("0.7.6", "A", &CompilerJsonConfig{
+		Language: "Solidity",
+		Sources: map[string]Source{
+			"A.sol": {Content: "pragma solidity ^0.7.0;\ncontract A {}"},
+			"B.sol": {Content: source},
+		},
+	})
+	assert.NoError(t, err)
+	jsonConfig.SetCheckVersionPragma(true)
+
+	compiler, err = NewCompiler(context.TODO(), s, jsonConfig, `{"language": "Solidity"}`)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, compiler.Validate(), "B.sol requires solidity ^0.8.0")
+}
+
+func TestCompilerResultDeployedBytecodeSize(t *testing.T) {
+	result := &CompilerResult{}
+	assert.Equal(t, 0, result.DeployedBytecodeSize())
+	assert.False(t, result.ExceedsSizeLimit())
+
+	result.DeployedBytecode = "0x6080604052"
+	assert.Equal(t, 5, result.DeployedBytecodeSize())
+
+	result.DeployedBytecode = "6080604052"
+	assert.Equal(t, 5, result.DeployedBytecodeSize())
+
+	result.DeployedBytecode = strings.Repeat("60", ContractSizeLimit)
+	assert.Equal(t, ContractSizeLimit, result.DeployedBytecodeSize())
+	assert.False(t, result.ExceedsSizeLimit())
+
+	result.DeployedBytecode = "0x" + strings.Repeat("60", ContractSizeLimit+1)
+	assert.True(t, result.ExceedsSizeLimit())
+}
+
+func TestCompileExecError(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	installFakeBinary(t, s, "0.8.20", `echo "Error: boom" >&2; exit 3`)
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+
+	var execErr *CompileExecError
+	if assert.ErrorAs(t, err, &execErr) {
+		assert.Equal(t, 3, execErr.ExitCode)
+		assert.Empty(t, execErr.Signal)
+		assert.False(t, execErr.IsCrash())
+		assert.Equal(t, "Error: boom\n", execErr.Stderr)
+		assert.EqualError(t, execErr, "solc exited with code 3")
+	}
+
+	installFakeBinary(t, s, "0.8.20", `kill -SEGV $$`)
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+
+	if assert.ErrorAs(t, err, &execErr) {
+		assert.Equal(t, -1, execErr.ExitCode)
+		assert.Equal(t, "segmentation fault", execErr.Signal)
+		assert.True(t, execErr.IsCrash())
+	}
+
+	installFakeBinary(t, s, "0.8.20", `echo "CompilerError: Stack too deep." >&2; exit 1`)
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.ErrorIs(t, err, ErrStackTooDeep)
+	assert.ErrorAs(t, err, &execErr)
+}
+
+func TestCompilerMaxSourceBytes(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "0.8.20"}'`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(DefaultMaxSourceBytes), config.GetMaxSourceBytes())
+
+	source := strings.Repeat("// generated\n", 100) + "contract A {}"
+
+	config.SetMaxSourceBytes(64)
+	_, err = NewCompiler(context.TODO(), s, config, source)
+	assert.ErrorIs(t, err, ErrSourceTooLarge)
+
+	// The limit is enforced at compile time as well, as the configuration may change in between.
+	config.SetMaxSourceBytes(-1)
+	compiler, err := NewCompiler(context.TODO(), s, config, source)
+	assert.NoError(t, err)
+
+	config.SetMaxSourceBytes(64)
+	_, err = compiler.Compile()
+	assert.ErrorIs(t, err, ErrSourceTooLarge)
+
+	// Streamed sources are checked while read.
+	compiler, err = NewCompilerFromReader(context.TODO(), s, config, strings.NewReader(source))
+	assert.NoError(t, err)
+	_, err = compiler.Compile()
+	assert.ErrorIs(t, err, ErrSourceTooLarge)
+
+	config.SetMaxSourceBytes(int64(len(source)))
+	compiler, err = NewCompilerFromReader(context.TODO(), s, config, strings.NewReader(source))
+	assert.NoError(t, err)
+	_, err = compiler.Compile()
+	assert.NoError(t, err)
+
+	config.SetMaxSourceBytes(0)
+	assert.Equal(t, int64(DefaultMaxSourceBytes), config.GetMaxSourceBytes())
+}
+
+func TestCompilerResultMode(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+
+	installFakeBinary(t, s, "0.8.20", `echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "0.8.20"}'`)
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	assert.Equal(t, ModeCombinedJSON, results.GetResults()[0].GetMode())
+
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"A.sol": {"A": {"abi": []}}}, "errors": [{"severity": "warning", "message": "global"}], "version": "0.8.20"}'`)
+	jsonConfig, err := NewCompilerConfigFromJSON("0.8.20", "A", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+
+	results, err = s.Compile(context.TODO(), `{"language": "Solidity"}`, jsonConfig)
+	assert.NoError(t, err)
+	for _, result := range results.GetResults() {
+		assert.Equal(t, ModeStandardJSON, result.GetMode())
+	}
+
+	installFakeBinary(t, s, "0.8.20", `echo "ParserError" >&2; exit 1`)
+	compiler, err := NewCompiler(context.TODO(), s, jsonConfig, `{"language": "Solidity"}`)
+	assert.NoError(t, err)
+
+	results, err = compiler.Compile()
+	assert.Error(t, err)
+	assert.Equal(t, ModeStandardJSON, results.GetResults()[0].GetMode())
+}
+
+func TestCompilerResultsRawOutput(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+
+	output := `{"contracts": {"A.sol": {"A": {"abi": [], "evm": {"bytecode": {"object": "6080"}}}}}, "version": "0.8.20"}`
+	installFakeBinary(t, s, "0.8.20", "cat > /dev/null; echo '"+output+"'")
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "A", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), `{"language": "Solidity"}`, config)
+	assert.NoError(t, err)
+	assert.Nil(t, results.GetRawOutput(), "raw output is only retained on request")
+
+	config.SetKeepRawOutput(true)
+	assert.True(t, config.IsKeepRawOutput())
+
+	results, err = s.Compile(context.TODO(), `{"language": "Solidity"}`, config)
+	assert.NoError(t, err)
+	assert.Equal(t, output+"\n", string(results.GetRawOutput()))
+}
+
+func TestCompilerAutoRepairBinaries(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho '{\"contracts\": {\"<stdin>:A\": {\"bin\": \"6080\", \"abi\": []}}, \"version\": \"0.8.20\"}'\n")
+
+	var downloads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		_, _ = w.Write(binary)
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t, Version{TagName: "v0.8.20", Assets: []Asset{
+		{Name: "solc-static-linux", BrowserDownloadURL: server.URL, Size: len(binary)},
+	}})
+	s.gOOSFunc = func() string { return "linux" }
+	s.limiter = nil
+	s.config.SetLogger(zap.NewNop())
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	// A half-downloaded binary can't be executed.
+	binaryPath := installFakeBinary(t, s, "0.8.20", "")
+	assert.NoError(t, os.WriteFile(binaryPath, []byte{0x7f, 'E', 'L'}, 0700)) // #nosec G306
+
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.ErrorIs(t, err, syscall.ENOEXEC)
+	assert.Equal(t, int32(0), downloads.Load(), "repair is opt-in")
+
+	s.config.SetAutoRepairBinaries(true)
+	assert.True(t, s.config.IsAutoRepairBinaries())
+
+	results, err := s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), downloads.Load())
+	if assert.NotNil(t, results) {
+		assert.Equal(t, "6080", results.GetResults()[0].GetBytecode())
+	}
+
+	// Crashes are repaired too, while sources failing to compile aren't.
+	installFakeBinary(t, s, "0.8.20", "kill -SEGV $$")
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), downloads.Load())
+
+	installFakeBinary(t, s, "0.8.20", "echo 'Error: Expected pragma' >&2; exit 1")
+	_, err = s.Compile(context.TODO(), "contract A {", config)
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), downloads.Load())
+}
+
+func TestCompilerResultBinaryHash(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "0.8.20"}'`)
+
+	checksum, err := s.BinaryChecksum("0.8.20")
+	assert.NoError(t, err)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	assert.Equal(t, checksum, results.GetResults()[0].GetCompilerBinaryHash())
+
+	installFakeBinary(t, s, "0.8.20", `echo "ParserError" >&2; exit 1`)
+	checksum, err = s.BinaryChecksum("0.8.20")
+	assert.NoError(t, err)
+
+	compiler, err := NewCompiler(context.TODO(), s, config, "contract A {")
+	assert.NoError(t, err)
+
+	results, err = compiler.Compile()
+	assert.Error(t, err)
+	assert.Equal(t, checksum, results.GetResults()[0].GetCompilerBinaryHash())
+}
+
+func TestCompilerEntryContractQualifiedName(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"a/Token.sol": {"Token": {"abi": []}}, "b/Token.sol": {"Token": {"abi": []}, "Other": {"abi": []}}}, "version": "0.8.20"}'`)
+
+	tests := []struct {
+		entry    string
+		expected []string
+	}{
+		{entry: "b/Token.sol:Token", expected: []string{"b/Token.sol:Token"}},
+		{entry: "a/Token.sol:Token", expected: []string{"a/Token.sol:Token"}},
+		{entry: "Other", expected: []string{"b/Token.sol:Other"}},
+		{entry: "Token", expected: []string{"a/Token.sol:Token", "b/Token.sol:Token"}},
+		{entry: "a/Token.sol:Other", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entry, func(t *testing.T) {
+			config, err := NewCompilerConfigFromJSON("0.8.20", tt.entry, &CompilerJsonConfig{Language: "Solidity"})
+			assert.NoError(t, err)
+
+			results, err := s.Compile(context.TODO(), `{"language": "Solidity"}`, config)
+			assert.NoError(t, err)
+
+			var entries []string
+			for _, result := range results.GetResults() {
+				if result.IsEntry() {
+					entries = append(entries, result.SourcePath+":"+result.ContractName)
+				}
+			}
+			assert.ElementsMatch(t, tt.expected, entries)
+		})
+	}
+
+	// In simple mode bare names refer to the compiled source, while imported sources need the qualified name.
+	installFakeBinary(t, s, "0.8.20", `echo '{"contracts": {"<stdin>:Token": {"bin": "", "abi": []}, "lib/Token.sol:Token": {"bin": "", "abi": []}}, "version": "0.8.20"}'`)
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	for entry, expected := range map[string]string{"Token": "<stdin>", "lib/Token.sol:Token": "lib/Token.sol"} {
+		config.SetEntrySourceName(entry)
+		results, err := s.Compile(context.TODO(), "contract Token {}", config)
+		assert.NoError(t, err)
+		if assert.NotNil(t, results.GetEntryContract()) {
+			assert.Equal(t, expected, results.GetEntryContract().SourcePath)
+		}
+	}
+}
+
+func TestCompilerSourceMaps(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+
+	installFakeBinary(t, s, "0.8.20", `[ "$3" = "bin,abi,srcmap,srcmap-runtime" ] || exit 1
+echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": [], "srcmap": "0:10:0:-:0", "srcmap-runtime": "0:5:0:-:0"}}, "version": "0.8.20"}'`)
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+	assert.NoError(t, config.AddCombinedJsonOutputs("srcmap", "srcmap-runtime"))
+
+	results, err := s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	if assert.NotNil(t, results) {
+		assert.Equal(t, "0:10:0:-:0", results.GetResults()[0].GetSourceMap())
+		assert.Equal(t, "0:5:0:-:0", results.GetResults()[0].GetDeployedSourceMap())
+	}
+
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"A.sol": {"A": {"abi": [], "evm": {"bytecode": {"sourceMap": "1:2:0"}, "deployedBytecode": {"sourceMap": "3:4:0"}}}}}, "version": "0.8.20"}'`)
+	jsonConfig, err := NewCompilerConfigFromJSON("0.8.20", "A", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+
+	results, err = s.Compile(context.TODO(), `{"language": "Solidity"}`, jsonConfig)
+	assert.NoError(t, err)
+	if assert.NotNil(t, results) {
+		assert.Equal(t, "1:2:0", results.GetResults()[0].GetSourceMap())
+		assert.Equal(t, "3:4:0", results.GetResults()[0].GetDeployedSourceMap())
+	}
+}
+
+func TestCompilerImportedContracts(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"Token.sol": {"Token": {"abi": []}}, "lib/ERC20.sol": {"ERC20": {"abi": []}}}, "errors": [{"severity": "warning", "message": "Global."}], "version": "0.8.20"}'`)
+
+	imported := func(results *CompilerResults) map[string]bool {
+		flags := make(map[string]bool)
+		for _, result := range results.GetResults() {
+			if result.ContractName != "" {
+				flags[result.ContractName] = result.IsImported()
+			}
+		}
+		return flags
+	}
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "Token", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), `{"language": "Solidity"}`, config)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"Token": false, "ERC20": true}, imported(results))
+
+	// Without an entry contract the entry source is unknown in standard-json mode.
+	config.SetEntrySourceName("")
+	results, err = s.Compile(context.TODO(), `{"language": "Solidity"}`, config)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"Token": false, "ERC20": false}, imported(results))
+
+	// In simple mode the compiled source is the entry one.
+	installFakeBinary(t, s, "0.8.20", `echo '{"contracts": {"<stdin>:Token": {"bin": "", "abi": []}, "lib/ERC20.sol:ERC20": {"bin": "", "abi": []}}, "version": "0.8.20"}'`)
+	simpleConfig, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	results, err = s.Compile(context.TODO(), "contract Token {}", simpleConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"Token": false, "ERC20": true}, imported(results))
+}
+
+func TestCompilerCheckFlagSupport(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.7"})
+	installFakeBinary(t, s, "0.8.7", `echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "0.8.7"}'`)
+
+	config, err := NewDefaultCompilerConfig("0.8.7")
+	assert.NoError(t, err)
+	config.AppendArguments("--include-path", "lib")
+
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err, "flags aren't checked unless enabled")
+
+	config.SetCheckFlagSupport(true)
+	assert.True(t, config.IsCheckFlagSupport())
+
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.ErrorIs(t, err, ErrUnsupportedFlag)
+	assert.ErrorContains(t, err, "flag --include-path not supported by solc 0.8.7")
+
+	config.SetArguments([]string{"--overwrite", "--combined-json", "bin,abi", "-"})
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+}
+
+func TestCompilerOpcodesAndAssembly(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+
+	installFakeBinary(t, s, "0.8.20", `[ "$3" = "bin,abi,asm,opcodes" ] || exit 1
+echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": [], "opcodes": "PUSH1 0x80", "asm": {".code": []}}}, "version": "0.8.20"}'`)
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+	assert.NoError(t, config.AddCombinedJsonOutputs("asm", "opcodes"))
+
+	results, err := s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	if assert.NotNil(t, results) {
+		assert.Equal(t, "PUSH1 0x80", results.GetResults()[0].GetOpcodes())
+		assert.JSONEq(t, `{".code": []}`, results.GetResults()[0].GetLegacyAssembly())
+	}
+
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"A.sol": {"A": {"abi": [], "evm": {"bytecode": {"opcodes": "PUSH1 0x80"}, "legacyAssembly": {".code": []}}}}}, "version": "0.8.20"}'`)
+	jsonConfig, err := NewCompilerConfigFromJSON("0.8.20", "A", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+
+	results, err = s.Compile(context.TODO(), `{"language": "Solidity"}`, jsonConfig)
+	assert.NoError(t, err)
+	if assert.NotNil(t, results) {
+		assert.Equal(t, "PUSH1 0x80", results.GetResults()[0].GetOpcodes())
+		assert.JSONEq(t, `{".code": []}`, results.GetResults()[0].GetLegacyAssembly())
+	}
+
+	// Without the outputs requested, nothing is populated.
+	installFakeBinary(t, s, "0.8.20", `echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "0.8.20"}'`)
+	config, err = NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	results, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	if assert.NotNil(t, results) {
+		assert.Empty(t, results.GetResults()[0].GetOpcodes())
+		assert.Empty(t, results.GetResults()[0].GetLegacyAssembly())
+	}
+}
+
+func TestCompilerWarningsAsErrors(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "errors": ["<stdin>:1:1: Warning: SPDX license identifier not provided in source file."], "version": "0.8.20"}'`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+	assert.Len(t, results.Warnings(), 1)
+
+	config.SetWarningsAsErrors(true)
+	assert.True(t, config.IsWarningsAsErrors())
+
+	results, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.ErrorIs(t, err, ErrCompilationWarnings)
+	assert.ErrorContains(t, err, "SPDX license identifier")
+	if assert.NotNil(t, results, "results are returned for inspection") {
+		assert.Equal(t, "6080", results.GetResults()[0].GetBytecode())
+	}
+
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"A.sol": {"A": {"abi": []}}}, "errors": [{"severity": "info", "message": "Contract code size"}], "version": "0.8.20"}'`)
+	jsonConfig, err := NewCompilerConfigFromJSON("0.8.20", "A", &CompilerJsonConfig{Language: "Solidity"})
+	assert.NoError(t, err)
+	jsonConfig.SetWarningsAsErrors(true)
+
+	results, err = s.Compile(context.TODO(), `{"language": "Solidity"}`, jsonConfig)
+	assert.NoError(t, err, "infos aren't warnings")
+	assert.Empty(t, results.Warnings())
+}
+
+func TestCompilerNormalizeSource(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	source := "\uFEFFpragma solidity ^0.8.0;\r\ncontract A {}\r\n"
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	compiler, err := NewCompiler(context.TODO(), s, config, source)
+	assert.NoError(t, err)
+	assert.Equal(t, source, compiler.GetSources(), "sources are kept as is by default")
+
+	config.SetNormalizeSource(true)
+	assert.True(t, config.IsNormalizeSource())
+
+	compiler, err = NewCompiler(context.TODO(), s, config, source)
+	assert.NoError(t, err)
+	assert.Equal(t, "pragma solidity ^0.8.0;\ncontract A {}\n", compiler.GetSources())
+
+	// Lone carriage returns and BOMs past the start are left untouched.
+	compiler, err = NewCompiler(context.TODO(), s, config, "contract A {}\r// \uFEFF")
+	assert.NoError(t, err)
+	assert.Equal(t, "contract A {}\r// \uFEFF", compiler.GetSources())
+}
+
+func TestCompilerNormalizeJsonSources(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {"A.sol": {"A": {"abi": []}}}, "version": "0.8.20+commit.a1b79de6"}'`)
+
+	jsonConfig := &CompilerJsonConfig{
+		Language: "Solidity",
+		Sources: map[string]Source{
+			"A.sol": {Content: "\uFEFFpragma solidity ^0.8.0;\r\ncontract A {}\r\n"},
+			"B.sol": {Content: "contract B {}"},
+		},
+		Settings: Settings{OutputSelection: DefaultOutputSelection()},
+	}
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "A", jsonConfig)
+	assert.NoError(t, err)
+	config.SetNormalizeSource(true)
+
+	input, err := jsonConfig.ToJSON()
+	assert.NoError(t, err)
+
+	compiler, err := NewCompiler(context.TODO(), s, config, "\uFEFF"+string(input))
+	assert.NoError(t, err)
+
+	var decoded CompilerJsonConfig
+	assert.NoError(t, json.Unmarshal([]byte(compiler.GetSources()), &decoded))
+	assert.Equal(t, "pragma solidity ^0.8.0;\ncontract A {}\n", decoded.Sources["A.sol"].Content)
+	assert.Equal(t, "contract B {}", decoded.Sources["B.sol"].Content)
+	assert.Equal(t, jsonConfig.Settings.OutputSelection, decoded.Settings.OutputSelection)
+
+	results, err := compiler.Compile()
+	assert.NoError(t, err)
+	assert.Equal(t, HashSource("pragma solidity ^0.8.0;\ncontract A {}\n"), results.GetSourceHashes()["A.sol"])
+
+	_, err = NewCompiler(context.TODO(), s, config, "not json")
+	assert.Error(t, err)
+}
@@ -3,15 +3,27 @@ package solc
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
 	"time"
+
+	"go.uber.org/zap"
 )
 
 const (
 	// httpClientTimeout defines a default timeout duration for the HTTP client.
 	httpClientTimeout = 10 * time.Second
+
+	// binaryFileMode defines the default file mode for downloaded solc binaries.
+	binaryFileMode os.FileMode = 0755
+
+	// maxReleasesPerPage defines the maximum, and default, number of releases fetched per GitHub API page.
+	maxReleasesPerPage = 100
+
+	// defaultRateLimit defines the default number of requests per second sent to GitHub.
+	defaultRateLimit = 2
 )
 
 // Config represents the configuration settings for solc-switch.
@@ -20,6 +32,18 @@ type Config struct {
 	releasesUrl         string
 	httpClientTimeout   time.Duration
 	personalAccessToken string
+	logger              *zap.Logger
+	quiet               bool
+	binaryFileMode      os.FileMode
+	offline             bool
+	hooks               Hooks
+	solcJSFallback      bool
+	releasesPerPage     int
+	rateLimit           float64
+	downloadMirrors     []string
+	autoRepairBinaries  bool
+	syncTimeout         time.Duration
+	readOnlyBinaries    bool
 }
 
 // Validate checks the validity of the configuration settings.
@@ -28,6 +52,14 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	// Syncing writes the releases and binaries into the releases path, so fail early rather than deep in the sync.
+	// Offline mode never syncs, allowing read-only releases paths, e.g. baked into container images.
+	if !c.offline {
+		if err := validateWritablePath(c.releasesPath); err != nil {
+			return fmt.Errorf("releases %w", err)
+		}
+	}
+
 	if c.releasesUrl == "" {
 		return fmt.Errorf("releases url is empty")
 	}
@@ -63,6 +95,17 @@ func (c *Config) SetReleasesPath(path string) error {
 	return nil
 }
 
+// SetReleasesPathOrCreate sets the path where releases will be stored, creating the directory tree with 0755
+// permissions when it doesn't exist yet.
+func (c *Config) SetReleasesPathOrCreate(path string) error {
+	// #nosec G301
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return fmt.Errorf("failed to create releases path: %w", err)
+	}
+
+	return c.SetReleasesPath(path)
+}
+
 // GetReleasesPath returns the path where releases are stored.
 func (c *Config) GetReleasesPath() string {
 	return c.releasesPath
@@ -73,6 +116,68 @@ func (c *Config) GetReleasesUrl() string {
 	return c.releasesUrl
 }
 
+// SetReleasesPerPage sets the number of releases fetched per GitHub API page. Values above the GitHub maximum of
+// 100 are capped, values below 1 restore the default of 100.
+func (c *Config) SetReleasesPerPage(n int) {
+	c.releasesPerPage = min(n, maxReleasesPerPage)
+}
+
+// GetReleasesPerPage returns the number of releases fetched per GitHub API page.
+func (c *Config) GetReleasesPerPage() int {
+	if c.releasesPerPage < 1 {
+		return maxReleasesPerPage
+	}
+
+	return c.releasesPerPage
+}
+
+// SetRateLimit sets the maximum number of requests per second sent when fetching releases and downloading
+// binaries, shared by all the operations of a Solc instance. A limit of zero or less disables the rate limiting,
+// e.g. for private mirrors. It's applied when the Solc instance is created.
+func (c *Config) SetRateLimit(requestsPerSecond float64) {
+	if requestsPerSecond <= 0 {
+		// Negative values denote the disabled limit while zero is kept for the default one.
+		c.rateLimit = -1
+		return
+	}
+
+	c.rateLimit = requestsPerSecond
+}
+
+// GetRateLimit returns the maximum number of requests per second, zero when the rate limiting is disabled.
+func (c *Config) GetRateLimit() float64 {
+	if c.rateLimit < 0 {
+		return 0
+	}
+
+	if c.rateLimit == 0 {
+		return defaultRateLimit
+	}
+
+	return c.rateLimit
+}
+
+// SetDownloadMirrors sets the URL templates binaries are downloaded from, tried in order until one succeeds. The
+// {version} placeholder is replaced with the cleaned version, e.g. 0.8.20, and {filename} with the name of the
+// GitHub release asset, e.g. solc-static-linux. When no mirrors are set, the GitHub download URL of the asset is
+// used, so it has to be listed explicitly to be part of the failover.
+func (c *Config) SetDownloadMirrors(mirrors []string) error {
+	for _, mirror := range mirrors {
+		u, err := url.Parse(expandMirrorURL(mirror, "0.0.0", "solc"))
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid download mirror %q", mirror)
+		}
+	}
+
+	c.downloadMirrors = append([]string(nil), mirrors...)
+	return nil
+}
+
+// GetDownloadMirrors returns the URL templates binaries are downloaded from.
+func (c *Config) GetDownloadMirrors() []string {
+	return c.downloadMirrors
+}
+
 // SetHttpClientTimeout sets the timeout duration for the HTTP client.
 func (c *Config) SetHttpClientTimeout(timeout time.Duration) {
 	c.httpClientTimeout = timeout
@@ -82,3 +187,115 @@ func (c *Config) SetHttpClientTimeout(timeout time.Duration) {
 func (c *Config) GetHttpClientTimeout() time.Duration {
 	return c.httpClientTimeout
 }
+
+// SetSyncTimeout sets the maximum duration of a whole Sync, covering the releases fetch and every binary download.
+// Unlike the HTTP client timeout it bounds the sync as a whole rather than each request. Zero means unlimited.
+func (c *Config) SetSyncTimeout(timeout time.Duration) {
+	c.syncTimeout = timeout
+}
+
+// GetSyncTimeout returns the maximum duration of a whole Sync, zero when it's unlimited.
+func (c *Config) GetSyncTimeout() time.Duration {
+	return c.syncTimeout
+}
+
+// SetLogger sets the logger used by solc-switch. When no logger is set, the global zap logger is used.
+func (c *Config) SetLogger(logger *zap.Logger) {
+	c.logger = logger
+}
+
+// GetLogger returns the logger used by solc-switch, falling back to the global zap logger when none is set.
+func (c *Config) GetLogger() *zap.Logger {
+	if c.logger == nil {
+		return zap.L()
+	}
+
+	return c.logger
+}
+
+// SetQuiet enables or disables quiet mode. In quiet mode informational sync logging is suppressed while errors
+// are still logged, independent of the logger level.
+func (c *Config) SetQuiet(quiet bool) {
+	c.quiet = quiet
+}
+
+// IsQuiet returns true if quiet mode is enabled.
+func (c *Config) IsQuiet() bool {
+	return c.quiet
+}
+
+// SetOffline enables or disables offline mode. In offline mode releases are never synced from the network,
+// including the automatic re-sync of a corrupt local releases cache.
+func (c *Config) SetOffline(offline bool) {
+	c.offline = offline
+}
+
+// IsOffline returns true if offline mode is enabled.
+func (c *Config) IsOffline() bool {
+	return c.offline
+}
+
+// SetHooks sets the callbacks fired on key sync, download and compile events.
+func (c *Config) SetHooks(hooks Hooks) {
+	c.hooks = hooks
+}
+
+// GetHooks returns the callbacks fired on key sync, download and compile events.
+func (c *Config) GetHooks() Hooks {
+	return c.hooks
+}
+
+// SetSolcJSFallback enables or disables the fallback to the emscripten soljson.js builds, executed via Node,
+// for the versions without a native binary distributed for the current platform. It requires node on PATH.
+func (c *Config) SetSolcJSFallback(fallback bool) {
+	c.solcJSFallback = fallback
+}
+
+// IsSolcJSFallback returns true if the fallback to the soljson.js builds is enabled.
+func (c *Config) IsSolcJSFallback() bool {
+	return c.solcJSFallback
+}
+
+// SetAutoRepairBinaries enables or disables the automatic repair of corrupt binaries. When solc fails to execute
+// or crashes during a compilation, e.g. because the binary was only partially downloaded, the binary is removed,
+// downloaded again and the compilation retried once. It has no effect in offline mode.
+func (c *Config) SetAutoRepairBinaries(repair bool) {
+	c.autoRepairBinaries = repair
+}
+
+// IsAutoRepairBinaries returns true if corrupt binaries are automatically repaired.
+func (c *Config) IsAutoRepairBinaries() bool {
+	return c.autoRepairBinaries
+}
+
+// SetBinaryFileMode sets the file mode applied to downloaded solc binaries.
+// Modes without the owner-execute bit are rejected as the binary would not be executable.
+func (c *Config) SetBinaryFileMode(mode os.FileMode) error {
+	if mode&0100 == 0 {
+		return fmt.Errorf("binary file mode %#o is missing the owner-execute bit", mode)
+	}
+
+	c.binaryFileMode = mode
+	return nil
+}
+
+// SetReadOnlyBinaries enables or disables removing the write permission of downloaded binaries once installed,
+// e.g. 0755 becomes 0555, so that a verified binary isn't accidentally overwritten or tampered with later on.
+// RemoveBinary is still able to remove them.
+func (c *Config) SetReadOnlyBinaries(readOnly bool) {
+	c.readOnlyBinaries = readOnly
+}
+
+// IsReadOnlyBinaries returns true if downloaded binaries are made read-only once installed.
+func (c *Config) IsReadOnlyBinaries() bool {
+	return c.readOnlyBinaries
+}
+
+// GetBinaryFileMode returns the file mode applied to downloaded solc binaries.
+func (c *Config) GetBinaryFileMode() os.FileMode {
+	if c.binaryFileMode == 0 {
+		return binaryFileMode
+	}
+
+	return c.binaryFileMode
+}
@@ -1,10 +1,14 @@
 package solc
 
 import (
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -54,6 +58,22 @@ func TestConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestConfig_ValidateReadOnlyOffline(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("directory permissions are not enforced for the current user")
+	}
+
+	readOnlyDir := t.TempDir()
+	assert.NoError(t, os.Chmod(readOnlyDir, 0500))
+	defer os.Chmod(readOnlyDir, 0700) // #nosec G302
+
+	config := &Config{releasesPath: readOnlyDir, releasesUrl: "https://valid.url"}
+	assert.ErrorContains(t, config.Validate(), "directory is not writable")
+
+	config.SetOffline(true)
+	assert.NoError(t, config.Validate())
+}
+
 func TestConfig_SetReleasesPath(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -86,9 +106,82 @@ func TestConfig_SetReleasesPath(t *testing.T) {
 	}
 }
 
+func TestConfig_SetReleasesPathOrCreate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "releases")
+
+	config := &Config{}
+	assert.NoError(t, config.SetReleasesPathOrCreate(path))
+	assert.Equal(t, path, config.GetReleasesPath())
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	// Existing directories are accepted as is.
+	assert.NoError(t, config.SetReleasesPathOrCreate(path))
+
+	file := filepath.Join(t.TempDir(), "file")
+	assert.NoError(t, os.WriteFile(file, nil, 0600))
+	assert.Error(t, config.SetReleasesPathOrCreate(file))
+}
+
 func TestConfig_SetHttpClientTimeout(t *testing.T) {
 	config := &Config{}
 	timeout := 5 * time.Second
 	config.SetHttpClientTimeout(timeout)
 	assert.Equal(t, timeout, config.GetHttpClientTimeout())
 }
+
+func TestConfig_SetSyncTimeout(t *testing.T) {
+	config := &Config{}
+	assert.Zero(t, config.GetSyncTimeout())
+
+	config.SetSyncTimeout(time.Minute)
+	assert.Equal(t, time.Minute, config.GetSyncTimeout())
+}
+
+func TestConfig_SetLogger(t *testing.T) {
+	config := &Config{}
+	assert.Equal(t, zap.L(), config.GetLogger())
+
+	logger := zap.NewNop()
+	config.SetLogger(logger)
+	assert.Equal(t, logger, config.GetLogger())
+}
+
+func TestConfig_SetBinaryFileMode(t *testing.T) {
+	config := &Config{}
+	assert.Equal(t, os.FileMode(0755), config.GetBinaryFileMode())
+
+	assert.NoError(t, config.SetBinaryFileMode(0750))
+	assert.Equal(t, os.FileMode(0750), config.GetBinaryFileMode())
+
+	assert.Error(t, config.SetBinaryFileMode(0644))
+	assert.Equal(t, os.FileMode(0750), config.GetBinaryFileMode())
+}
+
+func TestConfig_SetReleasesPerPage(t *testing.T) {
+	config := &Config{}
+	assert.Equal(t, 100, config.GetReleasesPerPage())
+
+	config.SetReleasesPerPage(50)
+	assert.Equal(t, 50, config.GetReleasesPerPage())
+
+	config.SetReleasesPerPage(500)
+	assert.Equal(t, 100, config.GetReleasesPerPage())
+
+	config.SetReleasesPerPage(0)
+	assert.Equal(t, 100, config.GetReleasesPerPage())
+}
+
+func TestConfig_SetRateLimit(t *testing.T) {
+	config := &Config{}
+	assert.Equal(t, float64(defaultRateLimit), config.GetRateLimit())
+
+	config.SetRateLimit(5)
+	assert.Equal(t, float64(5), config.GetRateLimit())
+
+	config.SetRateLimit(0)
+	assert.Equal(t, float64(0), config.GetRateLimit())
+	assert.Nil(t, newRateLimiter(config.GetRateLimit()))
+}
@@ -1,5 +1,10 @@
 package solc
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Distribution represents the type of operating system.
 type Distribution string
 
@@ -42,12 +47,19 @@ func (s *Solc) GetDistribution() Distribution {
 	}
 }
 
-// GetDistributionForAsset determines the appropriate asset name based on the operating system.
+// GetArchitecture returns the architecture the code is running on, as reported by runtime.GOARCH, e.g. "amd64"
+// or "arm64". Together with GetDistribution it identifies the platform binaries are selected for.
+func (s *Solc) GetArchitecture() string {
+	return s.gOArchFunc()
+}
+
+// GetDistributionForAsset determines the appropriate asset name based on the operating system and architecture.
 // This is useful for fetching the correct compiler binaries or assets.
 // Possible return values include:
 // - "solc-windows" for Windows.
-// - "solc-macos" for MacOS.
+// - "solc-macos" for MacOS, whose binaries are universal or run under Rosetta on arm64.
 // - "solc-static-linux" for Linux.
+// - "solc-static-linux-arm64" for Linux on arm64.
 // - "unknown" for unrecognized or unknown distributions.
 func (s *Solc) GetDistributionForAsset() string {
 	switch s.gOOSFunc() {
@@ -56,8 +68,55 @@ func (s *Solc) GetDistributionForAsset() string {
 	case "darwin":
 		return "solc-macos"
 	case "linux":
+		if s.GetArchitecture() == "arm64" {
+			return "solc-static-linux-arm64"
+		}
 		return "solc-static-linux"
 	default:
 		return "unknown"
 	}
 }
+
+// IsValidDistributionForAsset checks whether the provided distribution is one of the known asset distributions
+// returned by GetDistributionForAsset, excluding "unknown".
+func IsValidDistributionForAsset(distribution string) bool {
+	switch distribution {
+	case "solc-windows", "solc-macos", "solc-static-linux", "solc-static-linux-arm64":
+		return true
+	default:
+		return false
+	}
+}
+
+// isAssetForDistribution reports whether the release asset is the binary of the distribution. Asset names are
+// matched exactly, ignoring the ".exe" suffix, so that e.g. "solc-static-linux" doesn't pick the arm64 build.
+func isAssetForDistribution(assetName string, distribution string) bool {
+	return strings.TrimSuffix(assetName, ".exe") == distribution
+}
+
+// checkPlatformSupported returns ErrUnsupportedPlatform if there are no solc binaries distributed for the
+// operating system the code is running on.
+func (s *Solc) checkPlatformSupported() error {
+	if s.GetDistribution() == Unknown {
+		return fmt.Errorf(
+			"%w: no solc binaries are distributed for %s/%s", ErrUnsupportedPlatform, s.gOOSFunc(), s.gOArchFunc(),
+		)
+	}
+
+	return nil
+}
+
+// binaryFilename returns the local file name of the solc binary for the specified version on the current platform.
+func (s *Solc) binaryFilename(version string) string {
+	return binaryFilenameFor(version, s.GetDistributionForAsset())
+}
+
+// binaryFilenameFor returns the local file name of the solc binary for the specified version and distribution.
+// Windows binaries carry the ".exe" suffix, matching the "solc-windows.exe" release asset.
+func binaryFilenameFor(version string, distribution string) string {
+	filename := fmt.Sprintf("solc-%s", getCleanedVersionTag(version))
+	if distribution == "solc-windows" {
+		filename += ".exe"
+	}
+	return filename
+}
@@ -3,6 +3,7 @@ package solc
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -67,42 +68,163 @@ func TestGetDistributionForAsset(t *testing.T) {
 	tests := []struct {
 		name     string
 		goos     string
+		goarch   string
 		expected string
 	}{
 		{
 			name:     "Windows OS Asset",
 			goos:     "windows",
+			goarch:   "amd64",
 			expected: "solc-windows",
 		},
 		{
 			name:     "MacOS Asset",
 			goos:     "darwin",
+			goarch:   "amd64",
+			expected: "solc-macos",
+		},
+		{
+			name:     "MacOS Apple Silicon Asset",
+			goos:     "darwin",
+			goarch:   "arm64",
 			expected: "solc-macos",
 		},
 		{
 			name:     "Linux OS Asset",
 			goos:     "linux",
+			goarch:   "amd64",
 			expected: "solc-static-linux",
 		},
+		{
+			name:     "Linux ARM OS Asset",
+			goos:     "linux",
+			goarch:   "arm64",
+			expected: "solc-static-linux-arm64",
+		},
 		{
 			name:     "Unknown OS Asset",
 			goos:     "solaris", // Just an example of an OS that's not in our main switch case
+			goarch:   "amd64",
 			expected: "unknown",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			config, err := NewDefaultConfig()
-			assert.NoError(t, err)
-			assert.NotNil(t, config)
+			config := newTestConfig(t)
 
 			s, err := New(context.TODO(), config)
 			assert.NoError(t, err)
 			assert.NotNil(t, s)
 
 			s.gOOSFunc = func() string { return tt.goos }
+			s.gOArchFunc = func() string { return tt.goarch }
+			assert.Equal(t, tt.goarch, s.GetArchitecture())
 			assert.Equal(t, tt.expected, s.GetDistributionForAsset())
+			assert.True(t, tt.expected == "unknown" || IsValidDistributionForAsset(tt.expected))
+		})
+	}
+}
+
+func TestGetBinaryFor(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+
+	tests := []struct {
+		name         string
+		distribution string
+		filename     string
+		wantErr      bool
+	}{
+		{
+			name:         "Linux Binary",
+			distribution: "solc-static-linux",
+			filename:     "solc-0.8.20",
+		},
+		{
+			name:         "Windows Binary",
+			distribution: "solc-windows",
+			filename:     "solc-0.8.20.exe",
+		},
+		{
+			name:         "Unknown Distribution",
+			distribution: "unknown",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr {
+				_, err := s.GetBinaryFor("0.8.20", tt.distribution)
+				assert.Error(t, err)
+				assert.Error(t, s.SyncBinariesFor(nil, tt.distribution, ""))
+				return
+			}
+
+			// Binary is not downloaded yet.
+			_, err := s.GetBinaryFor("0.8.20", tt.distribution)
+			assert.Error(t, err)
+
+			expected := filepath.Join(s.GetConfig().GetReleasesPath(), tt.filename)
+			assert.NoError(t, os.WriteFile(expected, []byte{}, 0600))
+
+			binaryPath, err := s.GetBinaryFor("0.8.20", tt.distribution)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, binaryPath)
+		})
+	}
+}
+
+func TestIsAssetForDistribution(t *testing.T) {
+	assert.True(t, isAssetForDistribution("solc-static-linux", "solc-static-linux"))
+	assert.True(t, isAssetForDistribution("solc-static-linux-arm64", "solc-static-linux-arm64"))
+	assert.True(t, isAssetForDistribution("solc-windows.exe", "solc-windows"))
+	assert.False(t, isAssetForDistribution("solc-static-linux-arm64", "solc-static-linux"))
+	assert.False(t, isAssetForDistribution("solc-static-linux", "solc-static-linux-arm64"))
+	assert.False(t, isAssetForDistribution(solcJSAssetName, "solc-static-linux"))
+}
+
+func TestUnsupportedPlatform(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	s.gOOSFunc = func() string { return "freebsd" }
+	s.gOArchFunc = func() string { return "riscv64" }
+
+	err := s.Sync()
+	assert.ErrorIs(t, err, ErrUnsupportedPlatform)
+	assert.ErrorContains(t, err, "freebsd/riscv64")
+
+	assert.ErrorIs(t, s.SyncVersions([]string{"0.8.20"}), ErrUnsupportedPlatform)
+
+	assert.ErrorIs(t, s.SyncOne(&Version{TagName: "v0.8.20"}), ErrUnsupportedPlatform)
+	assert.ErrorIs(t, s.SyncBinaries(nil, ""), ErrUnsupportedPlatform)
+
+	_, err = s.GetBinary("0.8.20")
+	assert.ErrorIs(t, err, ErrUnsupportedPlatform)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.ErrorIs(t, err, ErrUnsupportedPlatform)
+}
+
+func TestBinaryFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		goos     string
+		version  string
+		expected string
+	}{
+		{name: "Windows", goos: "windows", version: "v0.8.20", expected: "solc-0.8.20.exe"},
+		{name: "MacOS", goos: "darwin", version: "0.8.20", expected: "solc-0.8.20"},
+		{name: "Linux", goos: "linux", version: "v0.8.20", expected: "solc-0.8.20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestSolc(t)
+			s.gOOSFunc = func() string { return tt.goos }
+			assert.Equal(t, tt.expected, s.binaryFilename(tt.version))
 		})
 	}
 }
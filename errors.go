@@ -0,0 +1,130 @@
+package solc
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"syscall"
+)
+
+var (
+	// ErrUnsupportedPlatform is returned when there are no solc binaries distributed for the current platform.
+	ErrUnsupportedPlatform = errors.New("unsupported platform")
+
+	// ErrStackTooDeep is returned when solc fails with the "Stack too deep" error. Enabling viaIR or the optimizer
+	// usually resolves it.
+	ErrStackTooDeep = errors.New("stack too deep, try enabling viaIR or the optimizer")
+
+	// ErrCompileTimeout is returned when the compilation exceeds the configured maximum compile duration.
+	ErrCompileTimeout = errors.New("compilation timed out")
+
+	// ErrCorruptReleaseCache is returned when the local releases.json cannot be parsed and it couldn't be rebuilt
+	// automatically, e.g. in offline mode. Calling SyncReleases rebuilds the cache.
+	ErrCorruptReleaseCache = errors.New("corrupt releases cache")
+
+	// ErrVersionPragmaMismatch is returned when the compiler version doesn't satisfy the version pragma of a source.
+	ErrVersionPragmaMismatch = errors.New("compiler version does not satisfy the version pragma")
+
+	// ErrBinaryNotInstalled is returned when the binary of the requested version isn't present in the local cache.
+	ErrBinaryNotInstalled = errors.New("binary not installed")
+
+	// ErrSourceTooLarge is returned when the source passed to solc exceeds the configured maximum source size.
+	ErrSourceTooLarge = errors.New("source too large")
+
+	// ErrUnsupportedFlag is returned when a configured argument isn't supported by the compiler version.
+	ErrUnsupportedFlag = errors.New("unsupported flag")
+
+	// ErrCompilationWarnings is returned, along with the results, when solc emits warnings while warnings are
+	// treated as errors.
+	ErrCompilationWarnings = errors.New("compilation emitted warnings")
+
+	// ErrOffline is returned when a network operation is attempted in offline mode.
+	ErrOffline = errors.New("network access is disabled in offline mode")
+)
+
+// BinaryNotFoundError is returned when the binary of the requested version isn't installed. It tells where the
+// binary was looked for, whether the version is listed in the cached releases and whether the releases had to be
+// re-synced to look it up, so that callers can guide users towards syncing the releases or installing the binary.
+// It matches ErrBinaryNotInstalled as well as the error the release lookup failed with, if any.
+type BinaryNotFoundError struct {
+	Version          string // The cleaned version the binary was looked for.
+	ExpectedPath     string // The path the binary was expected at.
+	ExistsInReleases bool   // Whether the version is listed in the cached releases.
+	Offline          bool   // Whether offline mode, preventing any download, is enabled.
+	SyncAttempted    bool   // Whether the releases were re-synced while looking the version up, e.g. as the cache was corrupt.
+	Err              error  // The error the release lookup failed with, nil when the version is listed.
+}
+
+// Error describes the missing binary along with the next step to install it.
+func (e *BinaryNotFoundError) Error() string {
+	msg := fmt.Sprintf("binary for version %s not found at %s", e.Version, e.ExpectedPath)
+
+	switch {
+	case e.Err != nil && !errors.Is(e.Err, errReleaseNotFound) && e.SyncAttempted:
+		return fmt.Sprintf("%s: releases couldn't be re-synced to look the version up: %v", msg, e.Err)
+	case e.Err != nil && !errors.Is(e.Err, errReleaseNotFound):
+		return fmt.Sprintf("%s: releases couldn't be looked up: %v", msg, e.Err)
+	case e.Offline:
+		return msg + ": offline mode is enabled, the binary has to be installed manually"
+	case !e.ExistsInReleases && e.SyncAttempted:
+		return msg + ": version is not listed in the releases, even after re-syncing them"
+	case !e.ExistsInReleases:
+		return msg + ": version is not listed in the cached releases, sync the releases to check whether it exists"
+	default:
+		return msg + ": version is available, sync it to download the binary"
+	}
+}
+
+// Is reports whether the target is ErrBinaryNotInstalled.
+func (e *BinaryNotFoundError) Is(target error) bool {
+	return target == ErrBinaryNotInstalled
+}
+
+// Unwrap returns the error the release lookup failed with.
+func (e *BinaryNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// CompileExecError is returned when the solc process exits unsuccessfully. It allows telling a crashed solc,
+// often caused by a corrupt binary, apart from sources failing to compile.
+type CompileExecError struct {
+	ExitCode int    // The exit code of solc, -1 when it was killed by a signal.
+	Signal   string // The signal which killed solc (e.g. "segmentation fault"), empty when it exited by itself.
+	Stderr   string // The standard error output of solc.
+	Err      error  // The underlying process error.
+}
+
+// newCompileExecError creates a CompileExecError out of the error returned when running solc. It returns nil for
+// errors other than an unsuccessful exit, such as solc failing to start.
+func newCompileExecError(err error, stderr string) *CompileExecError {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return nil
+	}
+
+	execErr := &CompileExecError{ExitCode: exitErr.ExitCode(), Stderr: stderr, Err: err}
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		execErr.Signal = status.Signal().String()
+	}
+
+	return execErr
+}
+
+// Error returns the exit code or the signal solc terminated with.
+func (e *CompileExecError) Error() string {
+	if e.Signal != "" {
+		return fmt.Sprintf("solc killed by signal: %s", e.Signal)
+	}
+
+	return fmt.Sprintf("solc exited with code %d", e.ExitCode)
+}
+
+// Unwrap returns the underlying process error.
+func (e *CompileExecError) Unwrap() error {
+	return e.Err
+}
+
+// IsCrash returns true if solc was killed by a signal rather than exiting by itself.
+func (e *CompileExecError) IsCrash() bool {
+	return e.Signal != ""
+}
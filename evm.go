@@ -0,0 +1,63 @@
+package solc
+
+import (
+	"sort"
+	"strings"
+)
+
+// evmVersionSupport maps each EVM version to the first solc version able to target it via --evm-version.
+var evmVersionSupport = map[string]string{
+	"homestead":        "0.4.21",
+	"tangerineWhistle": "0.4.21",
+	"spuriousDragon":   "0.4.21",
+	"byzantium":        "0.4.21",
+	"constantinople":   "0.4.21",
+	"petersburg":       "0.5.5",
+	"istanbul":         "0.5.13",
+	"berlin":           "0.8.5",
+	"london":           "0.8.7",
+	"paris":            "0.8.18",
+	"shanghai":         "0.8.20",
+	"cancun":           "0.8.24",
+	"prague":           "0.8.27",
+	"osaka":            "0.8.29",
+}
+
+// minimumVersionForEVM returns the first solc version able to target the provided EVM version. The EVM version
+// is matched case-insensitively. The second return value is false for unknown EVM versions.
+func minimumVersionForEVM(evmVersion string) (string, bool) {
+	for name, version := range evmVersionSupport {
+		if strings.EqualFold(name, evmVersion) {
+			return version, true
+		}
+	}
+	return "", false
+}
+
+// VersionsSupportingEVM returns the installed solc versions able to target the provided EVM version (e.g. "cancun"),
+// sorted from the oldest to the newest. It returns nil for unknown EVM versions.
+func (s *Solc) VersionsSupportingEVM(evmVersion string) []string {
+	minVersion, ok := minimumVersionForEVM(evmVersion)
+	if !ok {
+		return nil
+	}
+
+	installed, err := s.installedVersions()
+	if err != nil {
+		return nil
+	}
+
+	var versions []string
+	for _, version := range installed {
+		if cmp, err := CompareVersions(version, minVersion); err == nil && cmp >= 0 {
+			versions = append(versions, version)
+		}
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		cmp, _ := CompareVersions(versions[i], versions[j])
+		return cmp < 0
+	})
+
+	return versions
+}
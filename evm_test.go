@@ -0,0 +1,24 @@
+package solc
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionsSupportingEVM(t *testing.T) {
+	s := newTestSolc(t)
+	s.gOOSFunc = func() string { return "linux" }
+
+	for _, version := range []string{"0.8.9", "0.8.23", "0.8.24", "0.8.100", "0.5.0"} {
+		assert.NoError(t, os.WriteFile(s.BinaryPath(version), []byte{}, 0600))
+	}
+
+	assert.Equal(t, []string{"0.8.24", "0.8.100"}, s.VersionsSupportingEVM("cancun"))
+	assert.Equal(t, []string{"0.8.24", "0.8.100"}, s.VersionsSupportingEVM("Cancun"))
+	assert.Equal(t, []string{"0.8.9", "0.8.23", "0.8.24", "0.8.100"}, s.VersionsSupportingEVM("london"))
+	assert.Equal(t, []string{"0.5.0", "0.8.9", "0.8.23", "0.8.24", "0.8.100"}, s.VersionsSupportingEVM("byzantium"))
+	assert.Equal(t, []string{"0.8.100"}, s.VersionsSupportingEVM("osaka"))
+	assert.Nil(t, s.VersionsSupportingEVM("frontier"))
+}
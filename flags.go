@@ -0,0 +1,52 @@
+package solc
+
+// flagSupport defines the range of solc versions supporting a command line flag. Removed is empty for flags
+// still supported by the latest solc.
+type flagSupport struct {
+	since   string // The first solc version supporting the flag.
+	removed string // The first solc version no longer supporting the flag.
+}
+
+// flagSupportMatrix maps the solc flags which aren't supported by every version to the versions supporting them.
+// Flags absent from the matrix are assumed to be supported by every version.
+var flagSupportMatrix = map[string]flagSupport{
+	"--standard-json":     {since: "0.4.11"},
+	"--evm-version":       {since: "0.4.21"},
+	"--storage-layout":    {since: "0.5.13"},
+	"--ir":                {since: "0.6.0"},
+	"--metadata-hash":     {since: "0.6.0"},
+	"--no-optimize-yul":   {since: "0.6.0"},
+	"--yul-optimizations": {since: "0.7.2"},
+	"--include-path":      {since: "0.8.8"},
+	"--lsp":               {since: "0.8.11"},
+	"--ast-json":          {removed: "0.8.10"},
+}
+
+// SupportsFlag reports whether the specified solc version supports the provided command line flag, e.g. "--ir",
+// according to the maintained feature matrix. Flags absent from the matrix are assumed to be supported, while
+// versions which can't be resolved support none.
+func (s *Solc) SupportsFlag(version string, flag string) bool {
+	version, err := s.ResolveVersion(version)
+	if err != nil {
+		return false
+	}
+
+	support, ok := flagSupportMatrix[flag]
+	if !ok {
+		return true
+	}
+
+	if support.since != "" {
+		if cmp, err := CompareVersions(version, support.since); err != nil || cmp < 0 {
+			return false
+		}
+	}
+
+	if support.removed != "" {
+		if cmp, err := CompareVersions(version, support.removed); err != nil || cmp >= 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,21 @@
+package solc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupportsFlag(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"}, Version{TagName: "v0.8.21-nightly.2023.7.1", Prerelease: true})
+
+	assert.True(t, s.SupportsFlag("0.8.8", "--include-path"))
+	assert.False(t, s.SupportsFlag("0.8.7", "--include-path"))
+	assert.True(t, s.SupportsFlag("v0.6.0", "--ir"))
+	assert.False(t, s.SupportsFlag("0.5.17", "--ir"))
+	assert.True(t, s.SupportsFlag("0.8.9", "--ast-json"))
+	assert.False(t, s.SupportsFlag("0.8.10", "--ast-json"), "removed flags are reported as unsupported")
+	assert.True(t, s.SupportsFlag("0.4.10", "--optimize"), "flags absent from the matrix are supported")
+	assert.True(t, s.SupportsFlag(VersionLatest, "--lsp"))
+	assert.False(t, s.SupportsFlag("not-a-version", "--ir"))
+}
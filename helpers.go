@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -34,7 +35,173 @@ func validatePath(path string) error {
 	return nil
 }
 
+// validateWritablePath checks that files can be created within the provided directory by creating and removing
+// a probe file.
+func validateWritablePath(path string) error {
+	probe, err := os.CreateTemp(path, ".write-probe-*")
+	if err != nil {
+		return fmt.Errorf("directory is not writable: %s", path)
+	}
+
+	if err := probe.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(probe.Name())
+}
+
 // getCleanedVersionTag removes the "v" prefix from a version tag.
 func getCleanedVersionTag(versionTag string) string {
 	return strings.ReplaceAll(versionTag, "v", "")
 }
+
+// CompareVersions compares two solc versions with semantic versioning precedence, ignoring the "v" prefix and
+// build metadata (e.g. "+commit.a1b79de6"). Prerelease versions (e.g. "0.8.26-nightly.2024.5.1") sort before the
+// corresponding release. It returns -1 if a < b, 0 if a == b and 1 if a > b.
+func CompareVersions(a string, b string) (int, error) {
+	aParts, aPrerelease, err := splitVersion(a)
+	if err != nil {
+		return 0, err
+	}
+
+	bParts, bPrerelease, err := splitVersion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range aParts {
+		switch {
+		case aParts[i] < bParts[i]:
+			return -1, nil
+		case aParts[i] > bParts[i]:
+			return 1, nil
+		}
+	}
+
+	switch {
+	case aPrerelease == bPrerelease:
+		return 0, nil
+	case aPrerelease == "":
+		return 1, nil
+	case bPrerelease == "":
+		return -1, nil
+	}
+
+	return comparePrereleases(aPrerelease, bPrerelease), nil
+}
+
+// splitVersion splits the version into its numeric "major.minor.patch" parts and its prerelease suffix.
+func splitVersion(version string) ([3]int, string, error) {
+	core, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), "+")
+	core, prerelease, _ := strings.Cut(core, "-")
+
+	parts, err := parseVersionTag(core)
+	if err != nil {
+		return parts, "", fmt.Errorf("invalid version: %s", version)
+	}
+
+	return parts, prerelease, nil
+}
+
+// comparePrereleases compares two prerelease suffixes identifier by identifier. Numeric identifiers are compared
+// numerically and sort before alphanumeric ones, as defined by semantic versioning.
+func comparePrereleases(a string, b string) int {
+	aIdentifiers := strings.Split(a, ".")
+	bIdentifiers := strings.Split(b, ".")
+
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		aNum, aErr := strconv.Atoi(aIdentifiers[i])
+		bNum, bErr := strconv.Atoi(bIdentifiers[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				return compareInts(aNum, bNum)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if cmp := strings.Compare(aIdentifiers[i], bIdentifiers[i]); cmp != 0 {
+				return cmp
+			}
+		}
+	}
+
+	return compareInts(len(aIdentifiers), len(bIdentifiers))
+}
+
+// compareInts returns -1 if a < b, 0 if a == b and 1 if a > b.
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// parseVersionTag parses the "major.minor.patch" version tag into its numeric parts.
+func parseVersionTag(versionTag string) ([3]int, error) {
+	var parts [3]int
+
+	fields := strings.Split(getCleanedVersionTag(versionTag), ".")
+	if len(fields) != 3 {
+		return parts, fmt.Errorf("invalid version: %s", versionTag)
+	}
+
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, fmt.Errorf("invalid version: %s", versionTag)
+		}
+		parts[i] = n
+	}
+
+	return parts, nil
+}
+
+// writeFileAtomic writes data to a temporary file within the same directory and renames it over the target path,
+// so that readers never observe a partially written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	tmpName := tmp.Name()
+	cleanup := func() {
+		_ = os.Remove(tmpName)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		cleanup()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		cleanup()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		cleanup()
+		return err
+	}
+
+	if err := os.Chmod(tmpName, perm); err != nil {
+		cleanup()
+		return err
+	}
+
+	if err := os.Rename(tmpName, path); err != nil {
+		cleanup()
+		return err
+	}
+
+	return nil
+}
@@ -1,8 +1,12 @@
 package solc
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -69,3 +73,102 @@ func TestValidatePath(t *testing.T) {
 		})
 	}
 }
+
+// newTestSolc creates a new Solc instance backed by a temporary releases directory that contains
+// a releases.json with the provided versions. It allows testing without reaching GitHub.
+func newTestSolc(t *testing.T, versions ...Version) *Solc {
+	t.Helper()
+
+	tempDir := t.TempDir()
+
+	data, err := json.Marshal(versions)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "releases.json"), data, 0600))
+
+	config := newTestConfig(t)
+	assert.NoError(t, config.SetReleasesPath(tempDir))
+
+	s, err := New(context.TODO(), config)
+	assert.NoError(t, err)
+	return s
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+		wantErr  bool
+	}{
+		{a: "0.8.10", b: "0.8.9", expected: 1},
+		{a: "v0.8.9", b: "0.8.10", expected: -1},
+		{a: "v0.8.20", b: "0.8.20", expected: 0},
+		{a: "0.8.26-nightly.2024.5.1", b: "0.8.26", expected: -1},
+		{a: "0.8.26", b: "0.8.25-nightly.2024.5.1", expected: 1},
+		{a: "0.8.26-nightly.2024.5.10", b: "0.8.26-nightly.2024.5.9", expected: 1},
+		{a: "0.8.26-nightly.2024.5.1", b: "0.8.26-nightly.2024.5.1", expected: 0},
+		{a: "0.8.26-alpha", b: "0.8.26-alpha.1", expected: -1},
+		{a: "0.8.26-1", b: "0.8.26-alpha", expected: -1},
+		{a: "0.8.20+commit.a1b79de6", b: "v0.8.20", expected: 0},
+		{a: "0.8", b: "0.8.20", wantErr: true},
+		{a: "0.8.x-nightly", b: "0.8.20", wantErr: true},
+		{a: "0.8.20", b: "0.8.x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			cmp, err := CompareVersions(tt.a, tt.b)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cmp)
+		})
+	}
+}
+
+func TestValidateWritablePath(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, validateWritablePath(dir))
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "probe file must be removed")
+
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("directory permissions are not enforced for the current user")
+	}
+
+	readOnlyDir := t.TempDir()
+	assert.NoError(t, os.Chmod(readOnlyDir, 0500))
+	defer os.Chmod(readOnlyDir, 0700) // #nosec G302
+
+	assert.ErrorContains(t, validateWritablePath(readOnlyDir), "directory is not writable")
+}
+
+// newTestConfig returns the default configuration with the releases stored in a temporary directory, so that
+// tests never write into the releases directory of the repository.
+func newTestConfig(t *testing.T) *Config {
+	t.Helper()
+
+	config, err := NewDefaultConfig()
+	assert.NoError(t, err)
+	assert.NoError(t, config.SetReleasesPath(t.TempDir()))
+	return config
+}
+
+// installFakeBinary installs a shell script acting as the solc binary of the specified version, so that the
+// compilation pipeline can be tested without downloading real solc releases.
+func installFakeBinary(t *testing.T, s *Solc, version string, script string) string {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("fake solc binaries are shell scripts and are not supported on windows")
+	}
+
+	binaryPath := s.BinaryPath(version)
+	assert.NoError(t, os.WriteFile(binaryPath, []byte("#!/bin/sh\n"+script+"\n"), 0700)) // #nosec G306
+	return binaryPath
+}
@@ -0,0 +1,64 @@
+package solc
+
+import "time"
+
+// Hooks defines optional callbacks fired on key events, allowing to emit metrics and traces without the library
+// depending on any metrics package. Callbacks left nil are skipped. Download callbacks may be fired concurrently.
+type Hooks struct {
+	OnSyncStart        func()                                                    // Fired when a releases sync starts.
+	OnSyncEnd          func(versionCount int, duration time.Duration, err error) // Fired when a releases sync ends.
+	OnDownloadStart    func(version string)                                      // Fired when a binary download starts.
+	OnDownloadComplete func(version string, duration time.Duration)              // Fired when a binary download succeeds.
+	OnDownloadFail     func(version string, err error)                           // Fired when a binary download fails.
+	OnCompileStart     func(version string)                                      // Fired when a compilation starts.
+	OnCompileEnd       func(version string, duration time.Duration, err error)   // Fired when a compilation ends.
+}
+
+// syncStart fires the OnSyncStart callback if set.
+func (h Hooks) syncStart() {
+	if h.OnSyncStart != nil {
+		h.OnSyncStart()
+	}
+}
+
+// syncEnd fires the OnSyncEnd callback if set.
+func (h Hooks) syncEnd(versionCount int, duration time.Duration, err error) {
+	if h.OnSyncEnd != nil {
+		h.OnSyncEnd(versionCount, duration, err)
+	}
+}
+
+// downloadStart fires the OnDownloadStart callback if set.
+func (h Hooks) downloadStart(version string) {
+	if h.OnDownloadStart != nil {
+		h.OnDownloadStart(version)
+	}
+}
+
+// downloadComplete fires the OnDownloadComplete callback if set.
+func (h Hooks) downloadComplete(version string, duration time.Duration) {
+	if h.OnDownloadComplete != nil {
+		h.OnDownloadComplete(version, duration)
+	}
+}
+
+// downloadFail fires the OnDownloadFail callback if set.
+func (h Hooks) downloadFail(version string, err error) {
+	if h.OnDownloadFail != nil {
+		h.OnDownloadFail(version, err)
+	}
+}
+
+// compileStart fires the OnCompileStart callback if set.
+func (h Hooks) compileStart(version string) {
+	if h.OnCompileStart != nil {
+		h.OnCompileStart(version)
+	}
+}
+
+// compileEnd fires the OnCompileEnd callback if set.
+func (h Hooks) compileEnd(version string, duration time.Duration, err error) {
+	if h.OnCompileEnd != nil {
+		h.OnCompileEnd(version, duration, err)
+	}
+}
@@ -0,0 +1,94 @@
+package solc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestHooks(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/download":
+			_, _ = w.Write([]byte("#!/bin/sh\n"))
+		case r.URL.Query().Get("page") == "1":
+			_ = json.NewEncoder(w).Encode([]Version{{
+				TagName: "v0.8.20",
+				Assets:  []Asset{{Name: "solc-static-linux", BrowserDownloadURL: server.URL + "/download"}},
+			}})
+		default:
+			_, _ = w.Write([]byte("[]"))
+		}
+	}))
+	defer server.Close()
+
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	s := newTestSolc(t)
+	s.gOOSFunc = func() string { return "linux" }
+	s.config.releasesUrl = server.URL
+	s.config.SetLogger(zap.NewNop())
+	s.config.SetHooks(Hooks{
+		OnSyncStart: func() { record("sync:start") },
+		OnSyncEnd: func(versionCount int, duration time.Duration, err error) {
+			assert.Equal(t, 1, versionCount)
+			assert.NoError(t, err)
+			record("sync:end")
+		},
+		OnDownloadStart:    func(version string) { record("download:start:" + version) },
+		OnDownloadComplete: func(version string, duration time.Duration) { record("download:complete:" + version) },
+		OnDownloadFail:     func(version string, err error) { record("download:fail:" + version) },
+		OnCompileStart:     func(version string) { record("compile:start:" + version) },
+		OnCompileEnd: func(version string, duration time.Duration, err error) {
+			assert.NoError(t, err)
+			record("compile:end:" + version)
+		},
+	})
+
+	assert.NoError(t, s.Sync())
+
+	installFakeBinary(t, s, "0.8.20", `echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "0.8.20"}'`)
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	_, err = s.Compile(context.TODO(), "contract A {}", config)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"sync:start",
+		"sync:end",
+		"download:start:0.8.20",
+		"download:complete:0.8.20",
+		"compile:start:0.8.20",
+		"compile:end:0.8.20",
+	}, events)
+}
+
+func TestHooksUnset(t *testing.T) {
+	hooks := Hooks{}
+	assert.NotPanics(t, func() {
+		hooks.syncStart()
+		hooks.syncEnd(0, 0, nil)
+		hooks.downloadStart("0.8.20")
+		hooks.downloadComplete("0.8.20", 0)
+		hooks.downloadFail("0.8.20", nil)
+		hooks.compileStart("0.8.20")
+		hooks.compileEnd("0.8.20", 0, nil)
+	})
+}
@@ -0,0 +1,203 @@
+package solc
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// importRegex matches Solidity import directives in all of their forms:
+//   - import "path";
+//   - import "path" as Alias;
+//   - import * as Alias from "path";
+//   - import {A, B as C} from "path";
+var importRegex = regexp.MustCompile(`(?s)\bimport\s+(?:[^'";]*?\s*from\s*)?["']([^"']+)["'][^;]*;`)
+
+// stripComments removes single-line and multi-line comments from the provided Solidity source while keeping
+// string literals intact. Newlines within comments are preserved so that line numbers remain stable.
+func stripComments(source string) string {
+	var sb strings.Builder
+	sb.Grow(len(source))
+
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+
+		switch {
+		case c == '"' || c == '\'':
+			// Copy the string literal verbatim, honoring escape sequences.
+			sb.WriteByte(c)
+			for i++; i < len(source); i++ {
+				sb.WriteByte(source[i])
+				if source[i] == '\\' && i+1 < len(source) {
+					i++
+					sb.WriteByte(source[i])
+					continue
+				}
+				if source[i] == c || source[i] == '\n' {
+					break
+				}
+			}
+		case c == '/' && i+1 < len(source) && source[i+1] == '/':
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+			if i < len(source) {
+				sb.WriteByte('\n')
+			}
+		case c == '/' && i+1 < len(source) && source[i+1] == '*':
+			for i += 2; i < len(source) && !(source[i] == '*' && i+1 < len(source) && source[i+1] == '/'); i++ {
+				if source[i] == '\n' {
+					sb.WriteByte('\n')
+				}
+			}
+			i++
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return sb.String()
+}
+
+// parseImports returns the import paths of all import directives found in the provided Solidity source.
+// Imports within comments are ignored.
+func parseImports(source string) []string {
+	var imports []string
+	for _, match := range importRegex.FindAllStringSubmatch(stripComments(source), -1) {
+		imports = append(imports, match[1])
+	}
+	return imports
+}
+
+// resolveImportPath resolves the import path against the source unit name of the importing source, following
+// the solc rules: relative imports (starting with "./" or "../") are resolved against the directory of the
+// importing source unit, while any other import is used as is.
+func resolveImportPath(importingUnit string, importPath string) string {
+	if strings.HasPrefix(importPath, "./") || strings.HasPrefix(importPath, "../") {
+		return path.Clean(path.Join(path.Dir(importingUnit), importPath))
+	}
+
+	return importPath
+}
+
+// remapping represents a single solc import remapping in the "[context:]prefix=target" form.
+type remapping struct {
+	context string
+	prefix  string
+	target  string
+}
+
+// parseRemappings parses the provided remappings, returning an error for malformed entries.
+func parseRemappings(remappings []string) ([]remapping, error) {
+	var parsed []remapping
+	for _, r := range remappings {
+		left, target, ok := strings.Cut(r, "=")
+		if !ok || left == "" {
+			return nil, fmt.Errorf("invalid remapping: %s", r)
+		}
+
+		var context string
+		if ctx, prefix, found := strings.Cut(left, ":"); found {
+			context, left = ctx, prefix
+		}
+
+		if left == "" {
+			return nil, fmt.Errorf("invalid remapping: %s", r)
+		}
+
+		parsed = append(parsed, remapping{context: context, prefix: left, target: target})
+	}
+	return parsed, nil
+}
+
+// applyRemappings applies the best matching remapping to the import path. As solc does, the remapping with the
+// longest context wins, followed by the one with the longest prefix.
+func applyRemappings(remappings []remapping, importingUnit string, importPath string) string {
+	var best *remapping
+	for i, r := range remappings {
+		if !strings.HasPrefix(importingUnit, r.context) || !strings.HasPrefix(importPath, r.prefix) {
+			continue
+		}
+
+		if best == nil || len(r.context) > len(best.context) ||
+			(len(r.context) == len(best.context) && len(r.prefix) > len(best.prefix)) {
+			best = &remappings[i]
+		}
+	}
+
+	if best == nil {
+		return importPath
+	}
+
+	return best.target + strings.TrimPrefix(importPath, best.prefix)
+}
+
+// GatherSources reads the entry source file and recursively follows its import directives, resolving remappings,
+// and returns the full sources map ready to be used within CompilerJsonConfig. Sources are keyed by their source
+// unit name, their path relative to the base path, and non-relative imports are looked up from the base path, as
+// solc does with --base-path. Typically the base path is the project root, so that "lib/..." imports of sources
+// within "src/" are found. An empty base path stands for the directory of the entry file.
+func GatherSources(basePath string, entryPath string, remappings []string) (map[string]Source, error) {
+	parsedRemappings, err := parseRemappings(remappings)
+	if err != nil {
+		return nil, err
+	}
+
+	baseDir := basePath
+	if baseDir == "" {
+		baseDir = filepath.Dir(entryPath)
+	}
+
+	baseDir, err = filepath.Abs(baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	absEntryPath, err := filepath.Abs(entryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	entryUnit, err := filepath.Rel(baseDir, absEntryPath)
+	if err != nil || entryUnit == ".." || strings.HasPrefix(entryUnit, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("entry file %s is not within the base path %s", entryPath, basePath)
+	}
+	entryUnit = filepath.ToSlash(entryUnit)
+
+	sources := make(map[string]Source)
+	queue := []string{entryUnit}
+
+	for len(queue) > 0 {
+		unitName := queue[0]
+		queue = queue[1:]
+
+		if _, ok := sources[unitName]; ok {
+			continue
+		}
+
+		filename := filepath.FromSlash(unitName)
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(baseDir, filename)
+		}
+
+		content, err := os.ReadFile(filepath.Clean(filename))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read source %s: %w", unitName, err)
+		}
+
+		sources[unitName] = Source{Content: string(content)}
+
+		for _, importPath := range parseImports(string(content)) {
+			// Relative imports are resolved first and only then remapped, following the solc semantics.
+			importUnit := applyRemappings(parsedRemappings, unitName, resolveImportPath(unitName, importPath))
+			if _, ok := sources[importUnit]; !ok {
+				queue = append(queue, importUnit)
+			}
+		}
+	}
+
+	return sources, nil
+}
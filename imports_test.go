@@ -0,0 +1,144 @@
+package solc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImports(t *testing.T) {
+	source := `// SPDX-License-Identifier: MIT
+	pragma solidity ^0.8.0;
+
+	import "./A.sol";
+	import './B.sol' as B;
+	import * as C from "../C.sol";
+	import {D, E as F} from "@lib/D.sol";
+	import {
+		G
+	} from "lib/G.sol";
+	// import "./Commented.sol";
+	/* import "./Block.sol"; */
+
+	contract Importer {
+		string constant s = "import \"./String.sol\";";
+	}`
+
+	assert.Equal(t, []string{"./A.sol", "./B.sol", "../C.sol", "@lib/D.sol", "lib/G.sol"}, parseImports(source))
+}
+
+func TestResolveImportPath(t *testing.T) {
+	tests := []struct {
+		name          string
+		importingUnit string
+		importPath    string
+		expected      string
+	}{
+		{"Relative Import", "contracts/Token.sol", "./utils/Math.sol", "contracts/utils/Math.sol"},
+		{"Parent Import", "contracts/utils/Math.sol", "../Token.sol", "contracts/Token.sol"},
+		{"Root Relative Import", "Token.sol", "./Math.sol", "Math.sol"},
+		{"Direct Import", "contracts/Token.sol", "@oz/token/ERC20.sol", "@oz/token/ERC20.sol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolveImportPath(tt.importingUnit, tt.importPath))
+		})
+	}
+}
+
+func TestGatherSources(t *testing.T) {
+	baseDir := t.TempDir()
+
+	files := map[string]string{
+		"Token.sol":      `import "@oz/token/ERC20.sol"; import "./utils/Math.sol"; contract Token {}`,
+		"utils/Math.sol": `import "../Token.sol"; library Math {}`,
+		"node_modules/@openzeppelin/token/ERC20.sol":   `import "../utils/Context.sol"; contract ERC20 {}`,
+		"node_modules/@openzeppelin/utils/Context.sol": `abstract contract Context {}`,
+		"Unused.sol": `contract Unused {}`,
+	}
+
+	for name, content := range files {
+		filename := filepath.Join(baseDir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
+		assert.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+	}
+
+	sources, err := GatherSources("", filepath.Join(baseDir, "Token.sol"), []string{"@oz/=node_modules/@openzeppelin/"})
+	assert.NoError(t, err)
+
+	var names []string
+	for name := range sources {
+		names = append(names, name)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"Token.sol",
+		"utils/Math.sol",
+		"node_modules/@openzeppelin/token/ERC20.sol",
+		"node_modules/@openzeppelin/utils/Context.sol",
+	}, names)
+	assert.Equal(t, files["Token.sol"], sources["Token.sol"].Content)
+
+	_, err = GatherSources("", filepath.Join(baseDir, "Token.sol"), nil)
+	assert.Error(t, err)
+
+	_, err = GatherSources("", filepath.Join(baseDir, "Token.sol"), []string{"invalid"})
+	assert.Error(t, err)
+}
+
+func TestGatherSourcesBasePath(t *testing.T) {
+	root := t.TempDir()
+
+	files := map[string]string{
+		"src/Token.sol":          `import "lib/solmate/ERC20.sol"; import "./Math.sol"; contract Token {}`,
+		"src/Math.sol":           `library Math {}`,
+		"lib/solmate/ERC20.sol":  `import "@utils/Context.sol"; contract ERC20 {}`,
+		"lib/utils/Context.sol":  `abstract contract Context {}`,
+		"lib/solmate/Unused.sol": `contract Unused {}`,
+	}
+
+	for name, content := range files {
+		filename := filepath.Join(root, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
+		assert.NoError(t, os.WriteFile(filename, []byte(content), 0600))
+	}
+
+	sources, err := GatherSources(root, filepath.Join(root, "src", "Token.sol"), []string{"@utils/=lib/utils/"})
+	assert.NoError(t, err)
+
+	var names []string
+	for name := range sources {
+		names = append(names, name)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"src/Token.sol",
+		"src/Math.sol",
+		"lib/solmate/ERC20.sol",
+		"lib/utils/Context.sol",
+	}, names)
+
+	// Without the project root, "lib/..." is looked up next to the entry file.
+	_, err = GatherSources("", filepath.Join(root, "src", "Token.sol"), nil)
+	assert.ErrorContains(t, err, "lib/solmate/ERC20.sol")
+
+	_, err = GatherSources(filepath.Join(root, "lib"), filepath.Join(root, "src", "Token.sol"), nil)
+	assert.ErrorContains(t, err, "not within the base path")
+}
+
+func TestApplyRemappings(t *testing.T) {
+	remappings, err := parseRemappings([]string{
+		"@oz/=node_modules/@openzeppelin/",
+		"@oz/token/=vendor/token/",
+		"contracts/legacy:@oz/=node_modules/@openzeppelin-v3/",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "node_modules/@openzeppelin/utils/Context.sol", applyRemappings(remappings, "Token.sol", "@oz/utils/Context.sol"))
+	assert.Equal(t, "vendor/token/ERC20.sol", applyRemappings(remappings, "Token.sol", "@oz/token/ERC20.sol"))
+	assert.Equal(t, "node_modules/@openzeppelin-v3/token/ERC20.sol", applyRemappings(remappings, "contracts/legacy/Old.sol", "@oz/token/ERC20.sol"))
+	assert.Equal(t, "./Math.sol", applyRemappings(remappings, "Token.sol", "./Math.sol"))
+}
@@ -0,0 +1,27 @@
+package solc
+
+import (
+	"encoding/hex"
+	"hash"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// newSourceHasher returns a hasher computing the legacy Keccak-256 hash, as used by Ethereum and solc, of the data
+// written into it. It differs from the standardized SHA3-256 in the padding only.
+func newSourceHasher() hash.Hash {
+	return sha3.NewLegacyKeccak256()
+}
+
+// encodeSourceHash returns the "0x" prefixed, hex encoded digest of the hasher.
+func encodeSourceHash(h hash.Hash) string {
+	return "0x" + hex.EncodeToString(h.Sum(nil))
+}
+
+// HashSource returns the "0x" prefixed, hex encoded keccak256 hash of the source content, exactly as solc
+// records it in the "sources" section of the contract metadata.
+func HashSource(content string) string {
+	h := newSourceHasher()
+	_, _ = h.Write([]byte(content))
+	return encodeSourceHash(h)
+}
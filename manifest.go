@@ -0,0 +1,158 @@
+package solc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// installedVersionRegex matches the version part of installed binary file names.
+var installedVersionRegex = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+
+// Manifest represents a lockfile of installed solc binaries along with their SHA-256 checksums.
+type Manifest struct {
+	Distribution string          `json:"distribution"` // The distribution the binaries were installed for.
+	Binaries     []ManifestEntry `json:"binaries"`     // The installed binaries sorted by version.
+}
+
+// ManifestEntry represents a single installed solc binary within the Manifest.
+type ManifestEntry struct {
+	Version string `json:"version"` // The solc version, without the "v" prefix.
+	SHA256  string `json:"sha256"`  // The hex encoded SHA-256 checksum of the binary.
+}
+
+// Mismatch represents a binary whose local checksum differs from the one recorded in the manifest.
+// Actual is empty when the binary recorded in the manifest isn't installed locally.
+type Mismatch struct {
+	Version  string `json:"version"`  // The solc version, without the "v" prefix.
+	Expected string `json:"expected"` // The checksum recorded in the manifest.
+	Actual   string `json:"actual"`   // The checksum of the locally installed binary.
+}
+
+// ExportManifest produces a JSON manifest of all the installed solc binaries with their SHA-256 checksums.
+func (s *Solc) ExportManifest() ([]byte, error) {
+	versions, err := s.installedVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := Manifest{
+		Distribution: s.GetDistributionForAsset(),
+		Binaries:     []ManifestEntry{},
+	}
+
+	for _, version := range versions {
+		checksum, err := fileChecksum(s.BinaryPath(version))
+		if err != nil {
+			return nil, err
+		}
+
+		manifest.Binaries = append(manifest.Binaries, ManifestEntry{Version: version, SHA256: checksum})
+	}
+
+	return json.MarshalIndent(manifest, "", "  ")
+}
+
+// BinaryChecksum returns the hex encoded SHA-256 checksum of the installed binary of the specified version, the
+// same checksum recorded in the manifest and on the compilation results. It returns ErrBinaryNotInstalled if the
+// binary isn't cached.
+func (s *Solc) BinaryChecksum(version string) (string, error) {
+	version, err := s.ResolveVersion(version)
+	if err != nil {
+		return "", err
+	}
+
+	checksum, err := fileChecksum(s.BinaryPath(version))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: version %s", ErrBinaryNotInstalled, version)
+		}
+		return "", err
+	}
+
+	return checksum, nil
+}
+
+// VerifyAgainstManifest compares the installed solc binaries against the provided manifest and returns all the
+// binaries whose checksum differs or which aren't installed. Installed binaries absent from the manifest are ignored.
+func (s *Solc) VerifyAgainstManifest(manifest []byte) ([]Mismatch, error) {
+	var m Manifest
+	if err := json.Unmarshal(manifest, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if m.Distribution != "" && m.Distribution != s.GetDistributionForAsset() {
+		return nil, fmt.Errorf(
+			"manifest distribution %s does not match current distribution %s",
+			m.Distribution, s.GetDistributionForAsset(),
+		)
+	}
+
+	var mismatches []Mismatch
+	for _, entry := range m.Binaries {
+		version := getCleanedVersionTag(entry.Version)
+		binaryPath := s.BinaryPath(version)
+
+		checksum, err := fileChecksum(binaryPath)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				return nil, err
+			}
+			checksum = ""
+		}
+
+		if !strings.EqualFold(checksum, entry.SHA256) {
+			mismatches = append(mismatches, Mismatch{Version: version, Expected: entry.SHA256, Actual: checksum})
+		}
+	}
+
+	return mismatches, nil
+}
+
+// installedVersions returns the versions of all the solc binaries installed in the releases path, sorted by name.
+func (s *Solc) installedVersions() ([]string, error) {
+	entries, err := os.ReadDir(s.config.GetReleasesPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), "solc-") {
+			continue
+		}
+
+		version := strings.TrimSuffix(strings.TrimPrefix(entry.Name(), "solc-"), ".exe")
+		if !installedVersionRegex.MatchString(version) || entry.Name() != s.binaryFilename(version) {
+			continue
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Strings(versions)
+	return versions, nil
+}
+
+// fileChecksum returns the hex encoded SHA-256 checksum of the provided file.
+func fileChecksum(file string) (string, error) {
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
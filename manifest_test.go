@@ -0,0 +1,75 @@
+package solc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifest(t *testing.T) {
+	s := newTestSolc(t)
+	s.gOOSFunc = func() string { return "linux" }
+
+	releasesPath := s.GetConfig().GetReleasesPath()
+	assert.NoError(t, os.WriteFile(filepath.Join(releasesPath, "solc-0.8.20"), []byte("solc 0.8.20"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(releasesPath, "solc-0.8.19"), []byte("solc 0.8.19"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(releasesPath, "solc-0.8.18.exe"), []byte("windows"), 0600))
+
+	data, err := s.ExportManifest()
+	assert.NoError(t, err)
+
+	var manifest Manifest
+	assert.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Equal(t, "solc-static-linux", manifest.Distribution)
+	assert.Len(t, manifest.Binaries, 2)
+	assert.Equal(t, "0.8.19", manifest.Binaries[0].Version)
+	assert.Equal(t, "0.8.20", manifest.Binaries[1].Version)
+	assert.Len(t, manifest.Binaries[1].SHA256, 64)
+
+	mismatches, err := s.VerifyAgainstManifest(data)
+	assert.NoError(t, err)
+	assert.Empty(t, mismatches)
+
+	// Tamper with one binary and remove the other.
+	assert.NoError(t, os.WriteFile(filepath.Join(releasesPath, "solc-0.8.20"), []byte("tampered"), 0600))
+	assert.NoError(t, os.Remove(filepath.Join(releasesPath, "solc-0.8.19")))
+
+	mismatches, err = s.VerifyAgainstManifest(data)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []Mismatch{
+		{Version: "0.8.19", Expected: manifest.Binaries[0].SHA256, Actual: ""},
+		{Version: "0.8.20", Expected: manifest.Binaries[1].SHA256, Actual: func() string {
+			checksum, err := fileChecksum(filepath.Join(releasesPath, "solc-0.8.20"))
+			assert.NoError(t, err)
+			return checksum
+		}()},
+	}, mismatches)
+
+	_, err = s.VerifyAgainstManifest([]byte("not json"))
+	assert.Error(t, err)
+
+	s.gOOSFunc = func() string { return "darwin" }
+	_, err = s.VerifyAgainstManifest(data)
+	assert.Error(t, err)
+}
+
+func TestBinaryChecksum(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	s.gOOSFunc = func() string { return "linux" }
+
+	binary := []byte("solc 0.8.20")
+	assert.NoError(t, os.WriteFile(s.BinaryPath("0.8.20"), binary, 0600))
+
+	checksum := sha256.Sum256(binary)
+	actual, err := s.BinaryChecksum("v0.8.20")
+	assert.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(checksum[:]), actual)
+
+	_, err = s.BinaryChecksum("0.8.19")
+	assert.ErrorIs(t, err, ErrBinaryNotInstalled)
+}
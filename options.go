@@ -0,0 +1,129 @@
+package solc
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// Option configures the Solc instance created by New. A *Config is an Option as well, used as the base
+// configuration the other options are applied on top of.
+type Option interface {
+	apply(o *options) error
+}
+
+// options holds the state the options passed to New are applied to.
+type options struct {
+	config *Config
+	client *http.Client
+}
+
+// optionFunc adapts a function to the Option interface.
+type optionFunc func(o *options) error
+
+// apply calls the function with the options.
+func (f optionFunc) apply(o *options) error {
+	return f(o)
+}
+
+// apply sets the configuration as the base configuration of the options.
+func (c *Config) apply(o *options) error {
+	if c == nil {
+		return fmt.Errorf("config needs to be provided")
+	}
+
+	o.config = c
+	return nil
+}
+
+// WithReleasesPath sets the path where the releases and binaries are stored, see Config.SetReleasesPath.
+func WithReleasesPath(path string) Option {
+	return optionFunc(func(o *options) error {
+		return o.config.SetReleasesPath(path)
+	})
+}
+
+// WithHTTPClient sets the HTTP client used to fetch the releases, instead of the one created out of the configured
+// timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return optionFunc(func(o *options) error {
+		if client == nil {
+			return fmt.Errorf("http client needs to be provided")
+		}
+
+		o.client = client
+		return nil
+	})
+}
+
+// WithOfflineMode enables the offline mode, see Config.SetOffline.
+func WithOfflineMode() Option {
+	return optionFunc(func(o *options) error {
+		o.config.SetOffline(true)
+		return nil
+	})
+}
+
+// WithLogger sets the logger, see Config.SetLogger.
+func WithLogger(logger *zap.Logger) Option {
+	return optionFunc(func(o *options) error {
+		o.config.SetLogger(logger)
+		return nil
+	})
+}
+
+// WithRateLimit sets the maximum number of requests per second sent to GitHub, see Config.SetRateLimit.
+func WithRateLimit(requestsPerSecond float64) Option {
+	return optionFunc(func(o *options) error {
+		o.config.SetRateLimit(requestsPerSecond)
+		return nil
+	})
+}
+
+// newOptions applies the options on top of the base configuration, which is the *Config passed along the options
+// or the default configuration otherwise. The options are applied to a shallow copy of a provided *Config, so that
+// reusing the same base configuration for several instances doesn't leak the options between them.
+func newOptions(opts []Option) (*options, error) {
+	o := &options{}
+
+	hasOptions := false
+	for _, opt := range opts {
+		config, ok := opt.(*Config)
+		if !ok {
+			hasOptions = true
+			continue
+		}
+
+		if err := config.apply(o); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.config == nil {
+		config, err := NewDefaultConfig()
+		if err != nil {
+			return nil, err
+		}
+		o.config = config
+	} else if hasOptions {
+		base := *o.config
+		o.config = &base
+	}
+
+	for _, opt := range opts {
+		if _, ok := opt.(*Config); ok {
+			continue
+		}
+
+		if opt == nil {
+			return nil, fmt.Errorf("option needs to be provided")
+		}
+
+		if err := opt.apply(o); err != nil {
+			return nil, err
+		}
+	}
+
+	return o, nil
+}
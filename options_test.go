@@ -0,0 +1,67 @@
+package solc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	releasesPath := t.TempDir()
+	client := &http.Client{Timeout: time.Minute}
+	logger := zap.NewNop()
+
+	s, err := New(context.TODO(), WithReleasesPath(releasesPath), WithHTTPClient(client), WithOfflineMode(), WithLogger(logger), WithRateLimit(0))
+	assert.NoError(t, err)
+	if assert.NotNil(t, s) {
+		assert.Equal(t, releasesPath, s.GetConfig().GetReleasesPath())
+		assert.Same(t, client, s.GetHTTPClient())
+		assert.True(t, s.GetConfig().IsOffline())
+		assert.Same(t, logger, s.GetConfig().GetLogger())
+		assert.Nil(t, s.limiter)
+	}
+
+	// A provided config is the base the options are applied on, regardless of the order.
+	config, err := NewDefaultConfig()
+	assert.NoError(t, err)
+	config.SetHttpClientTimeout(time.Second)
+
+	s, err = New(context.TODO(), WithOfflineMode(), config, WithReleasesPath(releasesPath))
+	assert.NoError(t, err)
+	if assert.NotNil(t, s) {
+		assert.True(t, s.GetConfig().IsOffline())
+		assert.Equal(t, releasesPath, s.GetConfig().GetReleasesPath())
+		assert.Equal(t, time.Second, s.GetHTTPClient().Timeout)
+	}
+
+	// The options don't leak into the provided config, nor into other instances sharing it.
+	assert.False(t, config.IsOffline())
+	assert.NotEqual(t, releasesPath, config.GetReleasesPath())
+
+	other, err := New(context.TODO(), config, WithReleasesPath(t.TempDir()))
+	assert.NoError(t, err)
+	if assert.NotNil(t, other) {
+		assert.False(t, other.GetConfig().IsOffline())
+		assert.NotSame(t, s.GetConfig(), other.GetConfig())
+	}
+
+	// Without options the provided config is used as is.
+	other, err = New(context.TODO(), s.GetConfig())
+	assert.NoError(t, err)
+	if assert.NotNil(t, other) {
+		assert.Same(t, s.GetConfig(), other.GetConfig())
+	}
+
+	_, err = New(context.TODO(), WithReleasesPath("/invalid/path/that/does/not/exist"))
+	assert.Error(t, err)
+
+	_, err = New(context.TODO(), WithReleasesPath(releasesPath), WithHTTPClient(nil))
+	assert.Error(t, err)
+
+	_, err = New(context.TODO(), WithReleasesPath(releasesPath), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,233 @@
+package solc
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// pragmaSolidityRegex matches the version pragma of a Solidity source, capturing its constraint.
+	pragmaSolidityRegex = regexp.MustCompile(`\bpragma\s+solidity\s+([^;]+);`)
+
+	// pragmaOperatorSpaceRegex matches the spaces between a comparison operator and its version, which solc allows.
+	pragmaOperatorSpaceRegex = regexp.MustCompile(`(>=|<=|>|<|=|\^|~)\s+`)
+)
+
+// versionBound is a single comparison, e.g. ">=0.8.0", a version has to satisfy.
+type versionBound struct {
+	op      string
+	version [3]int
+}
+
+// matches reports whether the version satisfies the bound.
+func (b versionBound) matches(version [3]int) bool {
+	cmp := compareVersionParts(version, b.version)
+
+	switch b.op {
+	case ">=":
+		return cmp >= 0
+	case ">":
+		return cmp > 0
+	case "<=":
+		return cmp <= 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp == 0
+	}
+}
+
+// ExtractVersionPragmas returns the constraints of all the version pragmas declared within the provided Solidity
+// source, e.g. "^0.8.0" for `pragma solidity ^0.8.0;`. Pragmas within comments are ignored.
+func ExtractVersionPragmas(source string) []string {
+	var pragmas []string
+	for _, match := range pragmaSolidityRegex.FindAllStringSubmatch(stripComments(source), -1) {
+		pragmas = append(pragmas, strings.TrimSpace(match[1]))
+	}
+
+	return pragmas
+}
+
+// SatisfiesVersionPragma reports whether the compiler version satisfies the version pragma constraint, following
+// the semver range syntax accepted by solc: "^", "~", comparison operators, partial and "x" wildcard versions,
+// hyphen ranges and "||" alternatives. Prerelease suffixes of the version are ignored.
+func SatisfiesVersionPragma(version string, pragma string) (bool, error) {
+	parts, _, err := splitVersion(version)
+	if err != nil {
+		return false, err
+	}
+
+	for _, alternative := range strings.Split(pragma, "||") {
+		bounds, err := parseVersionRange(alternative)
+		if err != nil {
+			return false, fmt.Errorf("invalid version pragma %q: %w", pragma, err)
+		}
+
+		satisfied := true
+		for _, bound := range bounds {
+			if !bound.matches(parts) {
+				satisfied = false
+				break
+			}
+		}
+
+		if satisfied {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// parseVersionRange parses a range without alternatives into the bounds a version has to satisfy.
+func parseVersionRange(versionRange string) ([]versionBound, error) {
+	fields := strings.Fields(pragmaOperatorSpaceRegex.ReplaceAllString(versionRange, "$1"))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty range")
+	}
+
+	// Hyphen range, e.g. "0.8.0 - 0.8.20".
+	if len(fields) == 3 && fields[1] == "-" {
+		lower, _, err := parsePartialVersion(fields[0])
+		if err != nil {
+			return nil, err
+		}
+
+		upper, specified, err := parsePartialVersion(fields[2])
+		if err != nil {
+			return nil, err
+		}
+
+		bounds := []versionBound{{op: ">=", version: lower}}
+		if specified == 3 {
+			return append(bounds, versionBound{op: "<=", version: upper}), nil
+		}
+		if specified > 0 {
+			bounds = append(bounds, versionBound{op: "<", version: bumpVersionParts(upper, specified-1)})
+		}
+		return bounds, nil
+	}
+
+	var bounds []versionBound
+	for _, field := range fields {
+		comparatorBounds, err := parseVersionComparator(field)
+		if err != nil {
+			return nil, err
+		}
+		bounds = append(bounds, comparatorBounds...)
+	}
+
+	return bounds, nil
+}
+
+// parseVersionComparator expands a single comparator, e.g. "^0.8.0", into the bounds a version has to satisfy.
+func parseVersionComparator(comparator string) ([]versionBound, error) {
+	op := ""
+	for _, candidate := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(comparator, candidate) {
+			op = candidate
+			break
+		}
+	}
+
+	version, specified, err := parsePartialVersion(strings.TrimPrefix(comparator, op))
+	if err != nil {
+		return nil, err
+	}
+
+	// Fully wildcarded versions, e.g. "*", match anything.
+	if specified == 0 {
+		return nil, nil
+	}
+
+	lower := versionBound{op: ">=", version: version}
+
+	switch op {
+	case "^":
+		// Bump the first non-zero part, or the last specified one when all of them are zero.
+		index := specified - 1
+		for i := 0; i < specified; i++ {
+			if version[i] != 0 {
+				index = i
+				break
+			}
+		}
+		return []versionBound{lower, {op: "<", version: bumpVersionParts(version, index)}}, nil
+	case "~":
+		index := 1
+		if specified == 1 {
+			index = 0
+		}
+		return []versionBound{lower, {op: "<", version: bumpVersionParts(version, index)}}, nil
+	case ">=":
+		return []versionBound{lower}, nil
+	case "<":
+		return []versionBound{{op: "<", version: version}}, nil
+	case ">":
+		if specified == 3 {
+			return []versionBound{{op: ">", version: version}}, nil
+		}
+		return []versionBound{{op: ">=", version: bumpVersionParts(version, specified-1)}}, nil
+	case "<=":
+		if specified == 3 {
+			return []versionBound{{op: "<=", version: version}}, nil
+		}
+		return []versionBound{{op: "<", version: bumpVersionParts(version, specified-1)}}, nil
+	default:
+		if specified == 3 {
+			return []versionBound{{op: "=", version: version}}, nil
+		}
+		return []versionBound{lower, {op: "<", version: bumpVersionParts(version, specified-1)}}, nil
+	}
+}
+
+// parsePartialVersion parses a possibly partial version, e.g. "0.8" or "0.8.x", into its numeric parts along with
+// the number of leading parts specified. Unspecified parts are zero.
+func parsePartialVersion(version string) ([3]int, int, error) {
+	var parts [3]int
+
+	fields := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(fields) > 3 {
+		return parts, 0, fmt.Errorf("invalid version: %s", version)
+	}
+
+	specified := 0
+	for i, field := range fields {
+		if field == "x" || field == "X" || field == "*" {
+			break
+		}
+
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return parts, 0, fmt.Errorf("invalid version: %s", version)
+		}
+
+		parts[i] = n
+		specified++
+	}
+
+	return parts, specified, nil
+}
+
+// bumpVersionParts increments the version part at the index and zeroes the following ones.
+func bumpVersionParts(version [3]int, index int) [3]int {
+	version[index]++
+	for i := index + 1; i < len(version); i++ {
+		version[i] = 0
+	}
+
+	return version
+}
+
+// compareVersionParts returns -1 if a < b, 0 if a == b and 1 if a > b.
+func compareVersionParts(a [3]int, b [3]int) int {
+	for i := range a {
+		if cmp := compareInts(a[i], b[i]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,68 @@
+package solc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractVersionPragmas(t *testing.T) {
+	source := `// SPDX-License-Identifier: MIT
+// pragma solidity 0.4.0;
+pragma solidity ^0.8.0;
+pragma solidity >=0.8.4
+	<0.9.0;
+contract A {}`
+
+	assert.Equal(t, []string{"^0.8.0", ">=0.8.4\n\t<0.9.0"}, ExtractVersionPragmas(source))
+	assert.Nil(t, ExtractVersionPragmas("contract A {}"))
+}
+
+func TestSatisfiesVersionPragma(t *testing.T) {
+	tests := []struct {
+		version  string
+		pragma   string
+		expected bool
+	}{
+		{version: "0.8.20", pragma: "^0.8.0", expected: true},
+		{version: "0.7.6", pragma: "^0.8.0", expected: false},
+		{version: "0.9.0", pragma: "^0.8.0", expected: false},
+		{version: "0.8.20", pragma: "^0.8", expected: true},
+		{version: "0.0.4", pragma: "^0.0.3", expected: false},
+		{version: "1.5.0", pragma: "^1.2.3", expected: true},
+		{version: "0.8.20", pragma: "~0.8.10", expected: true},
+		{version: "0.9.0", pragma: "~0.8", expected: false},
+		{version: "0.8.20", pragma: "0.8.20", expected: true},
+		{version: "0.8.21", pragma: "=0.8.20", expected: false},
+		{version: "0.8.21", pragma: "0.8", expected: true},
+		{version: "0.8.21", pragma: "0.8.x", expected: true},
+		{version: "0.8.21", pragma: "*", expected: true},
+		{version: "0.8.4", pragma: ">=0.8.4 <0.9.0", expected: true},
+		{version: "0.8.4", pragma: ">= 0.8.4 < 0.9.0", expected: true},
+		{version: "0.9.0", pragma: ">=0.8.4 <0.9.0", expected: false},
+		{version: "0.8.4", pragma: ">0.8.4", expected: false},
+		{version: "0.8.30", pragma: ">0.8", expected: false},
+		{version: "0.9.0", pragma: ">0.8", expected: true},
+		{version: "0.8.30", pragma: "<=0.8", expected: true},
+		{version: "0.8.10", pragma: "0.8.0 - 0.8.10", expected: true},
+		{version: "0.8.11", pragma: "0.8.0 - 0.8.10", expected: false},
+		{version: "0.8.30", pragma: "0.8.0 - 0.8", expected: true},
+		{version: "0.6.12", pragma: "^0.5.0 || ^0.6.0", expected: true},
+		{version: "0.7.0", pragma: "^0.5.0 || ^0.6.0", expected: false},
+		{version: "v0.8.26-nightly.2024.5.1", pragma: "^0.8.26", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version+"_"+tt.pragma, func(t *testing.T) {
+			satisfied, err := SatisfiesVersionPragma(tt.version, tt.pragma)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, satisfied)
+		})
+	}
+
+	_, err := SatisfiesVersionPragma("0.8.20", "^0.8.a")
+	assert.ErrorContains(t, err, "invalid version pragma")
+
+	_, err = SatisfiesVersionPragma("0.8", "^0.8.0")
+	assert.Error(t, err)
+}
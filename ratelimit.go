@@ -0,0 +1,31 @@
+package solc
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitBurst defines the number of requests sent to GitHub at once. Requests are otherwise spaced out evenly
+// according to the configured rate, no matter how many goroutines issue them concurrently.
+const rateLimitBurst = 1
+
+// newRateLimiter returns a limiter allowing the provided number of requests per second, or nil when the rate
+// isn't positive.
+func newRateLimiter(requestsPerSecond float64) *rate.Limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), rateLimitBurst)
+}
+
+// waitRateLimit blocks until the next request to GitHub is allowed to be sent, or until the context is done. It
+// returns right away when the rate limiting is disabled.
+func (s *Solc) waitRateLimit(ctx context.Context) error {
+	if s.limiter == nil {
+		return nil
+	}
+
+	return s.limiter.Wait(ctx)
+}
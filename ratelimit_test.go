@@ -0,0 +1,89 @@
+package solc
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(50)
+
+	// Concurrent waiters share the limit: the fifth one waits four intervals of 20ms.
+	started := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, limiter.Wait(context.Background()))
+		}()
+	}
+	wg.Wait()
+	assert.GreaterOrEqual(t, time.Since(started), 80*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	slow := newRateLimiter(0.1)
+	assert.NoError(t, slow.Wait(context.Background()), "the first request isn't delayed")
+	assert.ErrorIs(t, slow.Wait(ctx), context.Canceled)
+
+	assert.Nil(t, newRateLimiter(0))
+	s := &Solc{}
+	assert.NoError(t, s.waitRateLimit(ctx), "disabled rate limiting doesn't wait")
+}
+
+// waitConcurrently makes the provided number of callers wait on the limiter at once and returns the delays after
+// which they were let through, in ascending order.
+func waitConcurrently(t *testing.T, limiter *rate.Limiter, callers int) []time.Duration {
+	t.Helper()
+
+	started := time.Now()
+	delays := make([]time.Duration, callers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, limiter.Wait(context.Background()))
+			delays[i] = time.Since(started)
+		}(i)
+	}
+	wg.Wait()
+
+	sort.Slice(delays, func(i, j int) bool { return delays[i] < delays[j] })
+	return delays
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	const interval = 40 * time.Millisecond
+	limiter := newRateLimiter(float64(time.Second / interval))
+
+	// Only a single request is let through at once, the others are spaced one interval apart.
+	delays := waitConcurrently(t, limiter, 4)
+	assert.Less(t, delays[0], interval/2, "the first request isn't delayed")
+	for i, delay := range delays {
+		assert.GreaterOrEqual(t, delay, time.Duration(i)*interval)
+	}
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	const interval = 40 * time.Millisecond
+	limiter := newRateLimiter(float64(time.Second / interval))
+
+	waitConcurrently(t, limiter, 3)
+
+	// Once idle, a request is let through right away again, but the idle time doesn't accumulate into a burst.
+	time.Sleep(3 * interval)
+
+	delays := waitConcurrently(t, limiter, 3)
+	assert.Less(t, delays[0], interval/2, "the limiter refills while idle")
+	assert.GreaterOrEqual(t, delays[1], interval)
+	assert.GreaterOrEqual(t, delays[2], 2*interval)
+}
@@ -4,10 +4,30 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// VersionLatest is the version keyword resolved to the newest stable release.
+	VersionLatest = "latest"
+
+	// VersionStable is the version keyword resolved to the newest stable release, an alias of VersionLatest.
+	VersionStable = "stable"
+
+	// VersionNightly is the version keyword resolved to the newest prerelease.
+	VersionNightly = "nightly"
 )
 
+// errReleaseNotFound is returned by GetRelease when the version isn't listed in the releases. Unlike other lookup
+// errors it doesn't mean the releases couldn't be read.
+var errReleaseNotFound = errors.New("version not found")
+
 // GetLocalReleasesPath returns the path to the local releases.json file.
 func (s *Solc) GetLocalReleasesPath() string {
 	return filepath.Join(s.config.GetReleasesPath(), "releases.json")
@@ -22,10 +42,50 @@ func (s *Solc) GetLocalReleases() ([]Version, error) {
 
 	var releases []Version
 	if err := json.Unmarshal(data, &releases); err != nil {
-		return nil, err
+		s.config.GetLogger().Error(
+			"Local releases cache is corrupt, it needs to be re-synced",
+			zap.String("path", s.GetLocalReleasesPath()),
+			zap.Error(err),
+		)
+
+		corruptErr := fmt.Errorf("%w: %w", ErrCorruptReleaseCache, err)
+		if s.config.IsOffline() {
+			return nil, corruptErr
+		}
+
+		return s.rebuildLocalReleases(data, corruptErr)
 	}
 
 	s.localReleases = releases
+	s.syncSource = SyncSourceDisk
+	s.loadSyncState()
+	return releases, nil
+}
+
+// rebuildLocalReleases backs up the corrupt releases.json next to it, as releases.json.bak, and re-syncs the
+// releases from the network to rebuild it. The corrupt file is left in place until the re-sync succeeds, so that
+// a failed re-sync keeps reporting the corruption.
+func (s *Solc) rebuildLocalReleases(data []byte, corruptErr error) ([]Version, error) {
+	backupPath := s.GetLocalReleasesPath() + ".bak"
+	if err := writeFileAtomic(backupPath, data, 0600); err != nil {
+		return nil, fmt.Errorf("%w: failed to back up releases cache: %w", corruptErr, err)
+	}
+
+	s.config.GetLogger().Warn(
+		"Re-syncing corrupt local releases cache",
+		zap.String("backup_path", backupPath),
+	)
+
+	// The corrupt cache can't be trusted, so bypass the sync throttling.
+	s.lastSync = time.Time{}
+	s.resyncs.Add(1)
+
+	// The releases are written through writeFileAtomic, replacing the corrupt file only once they're fetched.
+	releases, err := s.SyncReleases()
+	if err != nil {
+		return nil, fmt.Errorf("%w: re-sync failed: %w", corruptErr, err)
+	}
+
 	return releases, nil
 }
 
@@ -34,6 +94,13 @@ func (s *Solc) GetCachedReleases() []Version {
 	return s.localReleases
 }
 
+// ClearCache drops the releases cached in memory, so that the next lookup reloads them from releases.json. Unlike
+// a sync, it never reaches the network; the sync throttling is left untouched.
+func (s *Solc) ClearCache() {
+	s.localReleases = nil
+	s.syncSource = ""
+}
+
 // GetLatestRelease reads the memory cache or local releases.json file and returns the latest Solidity version.
 func (s *Solc) GetLatestRelease() (*Version, error) {
 	var versions []Version
@@ -57,10 +124,100 @@ func (s *Solc) GetLatestRelease() (*Version, error) {
 	return &versions[0], nil
 }
 
+// GetLatestStableRelease reads the memory cache or local releases.json file and returns the newest Solidity version
+// which is neither a prerelease nor a draft.
+func (s *Solc) GetLatestStableRelease() (*Version, error) {
+	return s.newestRelease(func(version Version) bool { return !version.Prerelease && !version.Draft })
+}
+
+// GetLatestPrerelease reads the memory cache or local releases.json file and returns the newest prerelease
+// (nightly) Solidity version.
+func (s *Solc) GetLatestPrerelease() (*Version, error) {
+	return s.newestRelease(func(version Version) bool { return version.Prerelease && !version.Draft })
+}
+
+// newestRelease returns the release with the highest version among the ones matching the filter.
+func (s *Solc) newestRelease(filter func(version Version) bool) (*Version, error) {
+	versions := s.GetCachedReleases()
+	if versions == nil {
+		localReleases, err := s.GetLocalReleases()
+		if err != nil {
+			return nil, err
+		}
+		versions = localReleases
+	}
+
+	var newest *Version
+	for i := range versions {
+		if !filter(versions[i]) {
+			continue
+		}
+
+		if newest == nil {
+			newest = &versions[i]
+			continue
+		}
+
+		if cmp, err := CompareVersions(versions[i].TagName, newest.TagName); err == nil && cmp > 0 {
+			newest = &versions[i]
+		}
+	}
+
+	if newest == nil {
+		return nil, errors.New("no matching version found in available releases")
+	}
+
+	return newest, nil
+}
+
+// isVersionAlias returns true if the version is one of the "latest", "stable" and "nightly" version keywords.
+func isVersionAlias(version string) bool {
+	switch version {
+	case VersionLatest, VersionStable, VersionNightly:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResolveVersion resolves the "latest" and "stable" version keywords into the concrete version of the newest stable
+// release, and the "nightly" keyword into the newest prerelease. Other versions are returned cleaned of the "v"
+// prefix.
+func (s *Solc) ResolveVersion(version string) (string, error) {
+	var (
+		release *Version
+		err     error
+	)
+
+	switch version {
+	case VersionLatest, VersionStable:
+		release, err = s.GetLatestStableRelease()
+	case VersionNightly:
+		release, err = s.GetLatestPrerelease()
+	default:
+		return getCleanedVersionTag(version), nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s version: %w", version, err)
+	}
+
+	return getCleanedVersionTag(release.TagName), nil
+}
+
 // GetRelease reads the memory cache or local releases.json file and returns the Solidity version matching the given tag name.
+// The version keywords are resolved first.
 func (s *Solc) GetRelease(tagName string) (*Version, error) {
 	var versions []Version
 
+	if isVersionAlias(tagName) {
+		resolved, err := s.ResolveVersion(tagName)
+		if err != nil {
+			return nil, err
+		}
+		tagName = resolved
+	}
+
 	tagName = getCleanedVersionTag(tagName)
 
 	if s.GetCachedReleases() == nil {
@@ -85,7 +242,84 @@ func (s *Solc) GetRelease(tagName string) (*Version, error) {
 		}
 	}
 
-	return nil, errors.New("version not found")
+	return nil, errReleaseNotFound
+}
+
+// GetReleaseNotes returns the GitHub release notes of the specified version.
+func (s *Solc) GetReleaseNotes(version string) (string, error) {
+	version, err := s.ResolveVersion(version)
+	if err != nil {
+		return "", err
+	}
+
+	release, err := s.GetRelease(version)
+	if err != nil {
+		return "", err
+	}
+
+	return release.Body, nil
+}
+
+// GetBreakingChanges returns the "Breaking Changes" section of the release notes of the specified version, without
+// its title. It returns an empty string when the release notes don't have such a section.
+func (s *Solc) GetBreakingChanges(version string) (string, error) {
+	notes, err := s.GetReleaseNotes(version)
+	if err != nil {
+		return "", err
+	}
+
+	return releaseNotesSection(notes, "breaking changes"), nil
+}
+
+// releaseNotesSection extracts the section with the provided title, matched case-insensitively, from the release
+// notes. Both markdown headings ("## Breaking Changes") and the changelog style ("Breaking Changes:") are
+// recognized as titles; the section ends at the next title.
+func releaseNotesSection(notes string, title string) string {
+	var (
+		section []string
+		inside  bool
+	)
+
+	for _, line := range strings.Split(strings.ReplaceAll(notes, "\r\n", "\n"), "\n") {
+		heading, isTitle := releaseNotesTitle(line)
+		if isTitle {
+			if inside {
+				break
+			}
+			inside = strings.EqualFold(heading, title)
+			continue
+		}
+
+		if inside {
+			section = append(section, line)
+		}
+	}
+
+	// Drop the blank lines around the section while keeping the indentation of its first line.
+	for len(section) > 0 && strings.TrimSpace(section[0]) == "" {
+		section = section[1:]
+	}
+	for len(section) > 0 && strings.TrimSpace(section[len(section)-1]) == "" {
+		section = section[:len(section)-1]
+	}
+
+	return strings.Join(section, "\n")
+}
+
+// releaseNotesTitle returns the title of the release notes line if it's a section title.
+func releaseNotesTitle(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+
+	if strings.HasPrefix(trimmed, "#") {
+		return strings.TrimSpace(strings.TrimSuffix(strings.TrimLeft(trimmed, "#"), ":")), true
+	}
+
+	// Changelog style titles aren't indented nor bullet points, e.g. "Compiler Features:".
+	if line == trimmed && strings.HasSuffix(trimmed, ":") && !strings.HasPrefix(trimmed, "*") && !strings.HasPrefix(trimmed, "-") {
+		return strings.TrimSuffix(trimmed, ":"), true
+	}
+
+	return "", false
 }
 
 // GetReleasesSimplified fetches the Solidity versions saved locally in releases.json and returns a simplified version info.
@@ -100,12 +334,66 @@ func (s *Solc) GetReleasesSimplified() ([]VersionInfo, error) {
 	// Return the first version as the latest release (assuming the list is sorted by release date)
 	var versionsInfo []VersionInfo
 	for _, version := range versions {
-		versionsInfo = append(versionsInfo, version.GetVersionInfo(versions[0].TagName))
+		versionInfo := version.GetVersionInfo(versions[0].TagName)
+		versionInfo.IsInstalled = s.IsInstalled(version.TagName)
+		versionsInfo = append(versionsInfo, versionInfo)
 	}
 
 	return versionsInfo, nil
 }
 
+// GetReleasesSimplifiedInRange returns the simplified version info of all the releases between minVersion and
+// maxVersion, both inclusive. An empty minVersion or maxVersion leaves the range unbounded on that side. The latest
+// release is still marked as latest even when it's outside of the range.
+func (s *Solc) GetReleasesSimplifiedInRange(minVersion string, maxVersion string) ([]VersionInfo, error) {
+	for _, bound := range []string{minVersion, maxVersion} {
+		if bound == "" {
+			continue
+		}
+		if _, err := parseVersionTag(bound); err != nil {
+			return nil, err
+		}
+	}
+
+	versionsInfo, err := s.GetReleasesSimplified()
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []VersionInfo
+	for _, versionInfo := range versionsInfo {
+		if minVersion != "" {
+			cmp, err := CompareVersions(versionInfo.TagName, minVersion)
+			if err != nil || cmp < 0 {
+				continue
+			}
+		}
+
+		if maxVersion != "" {
+			cmp, err := CompareVersions(versionInfo.TagName, maxVersion)
+			if err != nil || cmp > 0 {
+				continue
+			}
+		}
+
+		filtered = append(filtered, versionInfo)
+	}
+
+	return filtered, nil
+}
+
+// IsInstalled checks whether the binary of the specified version is present in the local binary cache. The version
+// keywords are resolved first; it returns false when they can't be.
+func (s *Solc) IsInstalled(version string) bool {
+	version, err := s.ResolveVersion(version)
+	if err != nil {
+		return false
+	}
+
+	info, err := os.Stat(s.BinaryPath(version))
+	return err == nil && !info.IsDir()
+}
+
 // GetBinary returns the path to the binary of the specified version.
 //
 // Parameters:
@@ -115,50 +403,168 @@ func (s *Solc) GetReleasesSimplified() ([]VersionInfo, error) {
 // - A string representing the path to the binary.
 // - An error if there's any issue during the fetch process or if the binary is not found.
 func (s *Solc) GetBinary(version string) (string, error) {
-	version = getCleanedVersionTag(version)
-	_, err := s.GetRelease(version)
-	if err != nil {
+	if err := s.checkPlatformSupported(); err != nil {
 		return "", err
 	}
 
-	filename := fmt.Sprintf("solc-%s", version)
-	distribution := s.GetDistributionForAsset()
-	if distribution == "solc-windows" {
-		filename += ".exe"
+	return s.GetBinaryFor(version, s.GetDistributionForAsset())
+}
+
+// GetBinaryFor returns the path to the binary of the specified version for the explicitly provided distribution.
+// Distribution is expected to be one of the values returned by GetDistributionForAsset.
+func (s *Solc) GetBinaryFor(version string, distribution string) (string, error) {
+	if !IsValidDistributionForAsset(distribution) {
+		return "", fmt.Errorf("invalid distribution provided: %s", distribution)
+	}
+
+	version, err := s.ResolveVersion(version)
+	if err != nil {
+		return "", err
 	}
 
-	binaryPath := filepath.Join(s.config.GetReleasesPath(), filename)
+	resyncs := s.resyncs.Load()
+	_, releaseErr := s.GetRelease(version)
+
+	binaryPath := filepath.Join(s.config.GetReleasesPath(), binaryFilenameFor(version, distribution))
 
 	if _, err := os.Stat(binaryPath); os.IsNotExist(err) {
-		return "", fmt.Errorf("binary for version %s not found", version)
+		return "", &BinaryNotFoundError{
+			Version:          version,
+			ExpectedPath:     binaryPath,
+			ExistsInReleases: releaseErr == nil,
+			Offline:          s.config.IsOffline(),
+			SyncAttempted:    s.resyncs.Load() != resyncs,
+			Err:              releaseErr,
+		}
+	}
+
+	if releaseErr != nil {
+		return "", releaseErr
 	}
 
 	return binaryPath, nil
 }
 
-// RemoveBinary removes the binary file of the specified version.
+// BinaryPath returns the path the binary of the specified version is, or would be, installed at for the current
+// distribution. Unlike GetBinary it doesn't check whether the binary exists. The "latest", "stable" and "nightly"
+// keywords are resolved against the cached releases first, an empty path is returned when they can't be resolved.
+func (s *Solc) BinaryPath(version string) string {
+	version, err := s.ResolveVersion(version)
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(s.config.GetReleasesPath(), s.binaryFilename(version))
+}
+
+// ExportBinary copies the installed binary of the specified version out of the local cache to the provided
+// destination path, preserving its file mode. It returns ErrBinaryNotInstalled if the binary isn't cached.
+// An existing file at the destination path is replaced.
+func (s *Solc) ExportBinary(version string, destPath string) error {
+	version, err := s.ResolveVersion(version)
+	if err != nil {
+		return err
+	}
+
+	binaryPath := s.BinaryPath(version)
+	source, err := os.Open(filepath.Clean(binaryPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: version %s", ErrBinaryNotInstalled, version)
+		}
+		return err
+	}
+	defer source.Close()
+
+	info, err := source.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		return fmt.Errorf("%w: version %s", ErrBinaryNotInstalled, version)
+	}
+
+	// Copy next to the destination and move it into place so that a failed copy never leaves a partial binary.
+	tmp, err := os.CreateTemp(filepath.Dir(destPath), "."+filepath.Base(destPath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(tmp, source); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to export binary for version %s: %w", version, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), destPath); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
+
+// RemoveBinary removes the binary file of the specified version. The version keywords are resolved first.
 func (s *Solc) RemoveBinary(version string) error {
-	version = getCleanedVersionTag(version)
-	_, err := s.GetRelease(version)
+	version, err := s.ResolveVersion(version)
 	if err != nil {
 		return err
 	}
 
-	filename := fmt.Sprintf("solc-%s", version)
-	distribution := s.GetDistributionForAsset()
-	if distribution == "solc-windows" {
-		filename += ".exe"
+	if _, err := s.GetRelease(version); err != nil {
+		return err
 	}
 
-	binaryPath := filepath.Join(s.config.GetReleasesPath(), filename)
+	binaryPath := s.BinaryPath(version)
 
 	if _, err := os.Stat(binaryPath); os.IsNotExist(err) {
 		return fmt.Errorf("binary for version %s not found", version)
 	}
 
-	if err := os.Remove(binaryPath); err != nil {
+	if err := removeBinaryFile(binaryPath); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// removeBinaryFile removes the binary, making it writable first when read-only files can't be removed, e.g. on
+// Windows.
+func removeBinaryFile(path string) error {
+	err := os.Remove(path)
+	if !os.IsPermission(err) {
+		return err
+	}
+
+	// #nosec G302
+	if chmodErr := os.Chmod(path, 0700); chmodErr != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// repairBinary removes the installed binary of the specified version and downloads it again.
+func (s *Solc) repairBinary(version string) error {
+	release, err := s.GetRelease(version)
+	if err != nil {
+		return err
+	}
+
+	if err := removeBinaryFile(s.BinaryPath(version)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return s.SyncBinaries([]Version{*release}, version)
+}
@@ -2,12 +2,17 @@ package solc
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 func TestAvailableVersions(t *testing.T) {
@@ -173,3 +178,367 @@ func TestInvalidLocalReleasesPath(t *testing.T) {
 	_, err = solc.GetLocalReleases()
 	assert.Error(t, err)
 }
+
+func TestGetSyncStatus(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"}, Version{TagName: "v0.8.19"})
+
+	status := s.GetSyncStatus()
+	assert.Equal(t, 0, status.VersionCount)
+	assert.Empty(t, status.Source)
+	assert.True(t, status.LastSync.IsZero())
+
+	_, err := s.GetLocalReleases()
+	assert.NoError(t, err)
+
+	status = s.GetSyncStatus()
+	assert.Equal(t, 2, status.VersionCount)
+	assert.Equal(t, SyncSourceDisk, status.Source)
+	assert.Empty(t, status.ETag)
+}
+
+func TestGetReleaseNotes(t *testing.T) {
+	s := newTestSolc(t,
+		Version{TagName: "v0.8.0", Body: "Solidity v0.8.0 is out!\r\n\r\nBreaking Changes:\r\n * Code Generator: Perform checked arithmetic.\r\n * Type System: Disallow explicit conversions.\r\n\r\nLanguage Features:\r\n * Allow renaming of symbols.\r\n"},
+		Version{TagName: "v0.8.1", Body: "## Breaking Changes\n- Remove `msg.gas`.\n\n## Bugfixes\n- Fix crash."},
+		Version{TagName: "v0.8.2", Body: "Compiler Features:\n * Faster.\n"},
+	)
+
+	notes, err := s.GetReleaseNotes("0.8.2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Compiler Features:\n * Faster.\n", notes)
+
+	breaking, err := s.GetBreakingChanges("v0.8.0")
+	assert.NoError(t, err)
+	assert.Equal(t, " * Code Generator: Perform checked arithmetic.\n * Type System: Disallow explicit conversions.", breaking)
+
+	breaking, err = s.GetBreakingChanges("0.8.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "- Remove `msg.gas`.", breaking)
+
+	breaking, err = s.GetBreakingChanges("0.8.2")
+	assert.NoError(t, err)
+	assert.Empty(t, breaking)
+
+	_, err = s.GetReleaseNotes("0.7.0")
+	assert.Error(t, err)
+}
+
+func TestClearCache(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+
+	release, err := s.GetLatestRelease()
+	assert.NoError(t, err)
+	assert.Equal(t, "v0.8.20", release.TagName)
+
+	// Another process updates releases.json behind our back.
+	data, err := json.Marshal([]Version{{TagName: "v0.8.21"}, {TagName: "v0.8.20"}})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(s.GetLocalReleasesPath(), data, 0600))
+
+	release, err = s.GetLatestRelease()
+	assert.NoError(t, err)
+	assert.Equal(t, "v0.8.20", release.TagName, "served from the memory cache")
+
+	s.ClearCache()
+	assert.Nil(t, s.GetCachedReleases())
+	assert.Empty(t, s.GetSyncStatus().Source)
+
+	release, err = s.GetLatestRelease()
+	assert.NoError(t, err)
+	assert.Equal(t, "v0.8.21", release.TagName)
+}
+
+func TestGetReleasesSimplifiedInRange(t *testing.T) {
+	s := newTestSolc(t,
+		Version{TagName: "v0.8.21"},
+		Version{TagName: "v0.8.10"},
+		Version{TagName: "v0.8.9"},
+		Version{TagName: "v0.7.6"},
+	)
+
+	tags := func(versionsInfo []VersionInfo) []string {
+		var result []string
+		for _, v := range versionsInfo {
+			result = append(result, v.TagName)
+		}
+		return result
+	}
+
+	versionsInfo, err := s.GetReleasesSimplifiedInRange("0.8.0", "0.8.20")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v0.8.10", "v0.8.9"}, tags(versionsInfo))
+
+	versionsInfo, err = s.GetReleasesSimplifiedInRange("0.8.10", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v0.8.21", "v0.8.10"}, tags(versionsInfo))
+	assert.True(t, versionsInfo[0].IsLatest)
+
+	versionsInfo, err = s.GetReleasesSimplifiedInRange("", "0.7.6")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"v0.7.6"}, tags(versionsInfo))
+
+	_, err = s.GetReleasesSimplifiedInRange("0.8", "")
+	assert.Error(t, err)
+}
+
+func TestExportBinary(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.21"}, Version{TagName: "v0.8.20"})
+	binaryPath := installFakeBinary(t, s, "0.8.20", "echo solc")
+
+	destDir := t.TempDir()
+	destPath := filepath.Join(destDir, "solc")
+	assert.NoError(t, os.WriteFile(destPath, []byte("stale"), 0600))
+
+	assert.NoError(t, s.ExportBinary("v0.8.20", destPath))
+
+	expected, err := os.ReadFile(binaryPath)
+	assert.NoError(t, err)
+	actual, err := os.ReadFile(destPath)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	info, err := os.Stat(destPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	assert.ErrorIs(t, s.ExportBinary("0.8.21", filepath.Join(destDir, "missing")), ErrBinaryNotInstalled)
+	assert.Error(t, s.ExportBinary("0.8.20", filepath.Join(destDir, "missing", "solc")))
+
+	entries, err := os.ReadDir(destDir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "failed exports must not leave files behind")
+}
+
+func TestIsInstalled(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.21"}, Version{TagName: "v0.8.20"})
+	s.gOOSFunc = func() string { return "linux" }
+
+	assert.NoError(t, os.WriteFile(filepath.Join(s.GetConfig().GetReleasesPath(), "solc-0.8.20"), []byte{}, 0600))
+
+	assert.True(t, s.IsInstalled("v0.8.20"))
+	assert.True(t, s.IsInstalled("0.8.20"))
+	assert.False(t, s.IsInstalled("0.8.21"))
+
+	versionsInfo, err := s.GetReleasesSimplified()
+	assert.NoError(t, err)
+	assert.Len(t, versionsInfo, 2)
+	assert.False(t, versionsInfo[0].IsInstalled)
+	assert.True(t, versionsInfo[1].IsInstalled)
+}
+
+func TestBinaryPath(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+
+	s.gOOSFunc = func() string { return "linux" }
+	assert.Equal(t, filepath.Join(s.GetConfig().GetReleasesPath(), "solc-0.8.20"), s.BinaryPath("v0.8.20"))
+
+	// The path is computed even though the binary isn't installed.
+	_, err := s.GetBinary("0.8.20")
+	assert.Error(t, err)
+
+	s.gOOSFunc = func() string { return "windows" }
+	assert.Equal(t, filepath.Join(s.GetConfig().GetReleasesPath(), "solc-0.8.20.exe"), s.BinaryPath("0.8.20"))
+
+	// Version keywords are resolved against the cached releases.
+	assert.Equal(t, s.BinaryPath("0.8.20"), s.BinaryPath(VersionLatest))
+	assert.Equal(t, s.BinaryPath("0.8.20"), s.BinaryPath(VersionStable))
+	assert.Empty(t, s.BinaryPath(VersionNightly), "no prerelease to resolve the keyword to")
+}
+
+func TestGetBinaryNotFound(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	s.gOOSFunc = func() string { return "linux" }
+
+	_, err := s.GetBinary("0.8.20")
+	assert.ErrorIs(t, err, ErrBinaryNotInstalled)
+	assert.ErrorContains(t, err, "version is available")
+
+	var notFound *BinaryNotFoundError
+	if assert.ErrorAs(t, err, &notFound) {
+		assert.Equal(t, "0.8.20", notFound.Version)
+		assert.Equal(t, s.BinaryPath("0.8.20"), notFound.ExpectedPath)
+		assert.True(t, notFound.ExistsInReleases)
+	}
+
+	_, err = s.GetBinary("0.8.19")
+	if assert.ErrorAs(t, err, &notFound) {
+		assert.False(t, notFound.ExistsInReleases)
+		assert.False(t, notFound.SyncAttempted)
+		assert.ErrorContains(t, err, "not listed in the cached releases")
+	}
+
+	s.config.SetOffline(true)
+	_, err = s.GetBinary("0.8.20")
+	assert.ErrorContains(t, err, "offline mode is enabled")
+}
+
+func TestCorruptReleaseCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			_, _ = w.Write([]byte(`[{"tag_name": "v0.8.20"}]`))
+			return
+		}
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t)
+	s.config.releasesUrl = server.URL
+	s.config.SetLogger(zap.NewNop())
+	s.config.SetOffline(true)
+
+	corrupt := []byte(`[{"tag_name": "v0.8`)
+	assert.NoError(t, os.WriteFile(s.GetLocalReleasesPath(), corrupt, 0600))
+
+	// In offline mode the corrupt cache can't be rebuilt.
+	_, err := s.GetLocalReleases()
+	assert.ErrorIs(t, err, ErrCorruptReleaseCache)
+
+	_, err = s.GetRelease("0.8.20")
+	assert.ErrorIs(t, err, ErrCorruptReleaseCache)
+
+	_, err = s.SyncReleases()
+	assert.ErrorIs(t, err, ErrOffline)
+
+	// Otherwise it's backed up and rebuilt by re-syncing the releases.
+	s.config.SetOffline(false)
+	s.lastSync = time.Now()
+
+	release, err := s.GetRelease("0.8.20")
+	assert.NoError(t, err)
+	assert.Equal(t, "v0.8.20", release.TagName)
+
+	backup, err := os.ReadFile(s.GetLocalReleasesPath() + ".bak")
+	assert.NoError(t, err)
+	assert.Equal(t, corrupt, backup)
+
+	s.localReleases = nil
+	releases, err := s.GetLocalReleases()
+	assert.NoError(t, err)
+	assert.Len(t, releases, 1)
+}
+
+func TestCorruptReleaseCacheResyncFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t)
+	s.config.releasesUrl = server.URL
+	s.config.SetLogger(zap.NewNop())
+
+	assert.NoError(t, os.WriteFile(s.GetLocalReleasesPath(), []byte(`{`), 0600))
+
+	_, err := s.GetLocalReleases()
+	assert.ErrorIs(t, err, ErrCorruptReleaseCache)
+	assert.ErrorContains(t, err, "re-sync failed")
+
+	// The original file is kept along the backup, so later lookups still report the corruption.
+	data, err := os.ReadFile(s.GetLocalReleasesPath())
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{`), data)
+
+	backup, err := os.ReadFile(s.GetLocalReleasesPath() + ".bak")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{`), backup)
+
+	_, err = s.GetRelease("0.8.20")
+	assert.ErrorIs(t, err, ErrCorruptReleaseCache)
+
+	// Looking the binary up reports the failed lookup rather than the version as unlisted.
+	s.gOOSFunc = func() string { return "linux" }
+	_, err = s.GetBinary("0.8.20")
+	assert.ErrorIs(t, err, ErrBinaryNotInstalled)
+	assert.ErrorIs(t, err, ErrCorruptReleaseCache)
+	assert.ErrorContains(t, err, "releases couldn't be re-synced")
+
+	var notFound *BinaryNotFoundError
+	if assert.ErrorAs(t, err, &notFound) {
+		assert.True(t, notFound.SyncAttempted)
+		assert.False(t, notFound.ExistsInReleases)
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	s := newTestSolc(t,
+		Version{TagName: "v0.8.27-nightly.2024.5.1", Prerelease: true},
+		Version{TagName: "v0.8.9"},
+		Version{TagName: "v0.8.26"},
+		Version{TagName: "v0.8.28", Draft: true},
+	)
+	s.gOOSFunc = func() string { return "linux" }
+
+	stable, err := s.GetLatestStableRelease()
+	assert.NoError(t, err)
+	assert.Equal(t, "v0.8.26", stable.TagName)
+
+	prerelease, err := s.GetLatestPrerelease()
+	assert.NoError(t, err)
+	assert.Equal(t, "v0.8.27-nightly.2024.5.1", prerelease.TagName)
+
+	version, err := s.ResolveVersion(VersionLatest)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8.26", version)
+
+	version, err = s.ResolveVersion(VersionNightly)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8.27-nightly.2024.5.1", version)
+
+	version, err = s.ResolveVersion("v0.8.9")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8.9", version)
+
+	assert.NoError(t, os.WriteFile(s.BinaryPath("0.8.26"), []byte{}, 0600))
+	binaryPath, err := s.GetBinary(VersionLatest)
+	assert.NoError(t, err)
+	assert.Equal(t, s.BinaryPath("0.8.26"), binaryPath)
+
+	s = newTestSolc(t, Version{TagName: "v0.8.26"})
+	_, err = s.ResolveVersion(VersionNightly)
+	assert.ErrorContains(t, err, "failed to resolve nightly version")
+}
+
+func TestVersionAliases(t *testing.T) {
+	s := newTestSolc(t,
+		Version{TagName: "v0.8.27-nightly.2024.5.1", Prerelease: true},
+		Version{TagName: "v0.8.26"},
+		Version{TagName: "v0.8.25"},
+	)
+	s.gOOSFunc = func() string { return "linux" }
+
+	version, err := s.ResolveVersion(VersionStable)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8.26", version)
+
+	for alias, expected := range map[string]string{
+		VersionLatest:  "v0.8.26",
+		VersionStable:  "v0.8.26",
+		VersionNightly: "v0.8.27-nightly.2024.5.1",
+	} {
+		release, err := s.GetRelease(alias)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, release.TagName, alias)
+	}
+
+	assert.False(t, s.IsInstalled(VersionStable))
+	assert.NoError(t, os.WriteFile(s.BinaryPath("0.8.26"), []byte{}, 0600))
+	assert.True(t, s.IsInstalled(VersionStable))
+
+	binaryPath, err := s.GetBinary(VersionStable)
+	assert.NoError(t, err)
+	assert.Equal(t, s.BinaryPath("0.8.26"), binaryPath)
+
+	assert.NoError(t, s.RemoveBinary(VersionLatest))
+	assert.False(t, s.IsInstalled("0.8.26"))
+
+	config, err := NewDefaultCompilerConfig(VersionStable)
+	assert.NoError(t, err)
+	assert.Equal(t, VersionStable, config.GetCompilerVersion())
+
+	s = newTestSolc(t, Version{TagName: "v0.8.26"})
+	_, err = s.GetRelease(VersionNightly)
+	assert.ErrorContains(t, err, "failed to resolve nightly version")
+	assert.False(t, s.IsInstalled(VersionNightly))
+}
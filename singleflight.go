@@ -0,0 +1,46 @@
+package solc
+
+import "sync"
+
+// flightGroup coalesces concurrent calls sharing the same key, so that only the first one runs while the others
+// wait for it and share its error. The zero value is ready to use.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// flightCall is an in-flight, or completed, call of a flightGroup.
+type flightCall struct {
+	done chan struct{}
+	err  error
+}
+
+// Do runs fn unless a call with the same key is already in flight, in which case it waits for that call to
+// complete and returns its error instead. It reports whether the error is shared with another caller's call.
+func (g *flightGroup) Do(key string, fn func() error) (shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-call.done
+		return true, call.err
+	}
+
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	// Forget the call before waking up the waiters so that later callers start a fresh one.
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.err = fn()
+	return false, call.err
+}
@@ -0,0 +1,60 @@
+package solc
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlightGroup(t *testing.T) {
+	var group flightGroup
+	var calls, sharedCount atomic.Int64
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	failure := errors.New("download failed")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		shared, err := group.Do("0.8.20", func() error {
+			calls.Add(1)
+			close(started)
+			<-release
+			return failure
+		})
+		assert.False(t, shared)
+		assert.ErrorIs(t, err, failure)
+	}()
+	<-started
+
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shared, err := group.Do("0.8.20", func() error {
+				calls.Add(1)
+				return nil
+			})
+			if shared {
+				sharedCount.Add(1)
+				assert.ErrorIs(t, err, failure)
+			}
+		}()
+	}
+
+	// Calls with another key aren't blocked by the in-flight one.
+	shared, err := group.Do("0.8.19", func() error { return nil })
+	assert.False(t, shared)
+	assert.NoError(t, err)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(4), calls.Load()+sharedCount.Load())
+	assert.Empty(t, group.calls)
+}
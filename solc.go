@@ -2,10 +2,15 @@ package solc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
 // Solc represents the main structure for interacting with the Solidity compiler.
@@ -14,29 +19,63 @@ type Solc struct {
 	ctx           context.Context
 	config        *Config
 	client        *http.Client
+	limiter       *rate.Limiter
 	gOOSFunc      func() string
+	gOArchFunc    func() string
 	localReleases []Version
 	lastSync      time.Time
+	syncSource    string
+	etag          string
+
+	workDirsMu sync.Mutex
+	workDirs   map[string]*projectDir // Working directories of CompileSources keyed by the project identifier.
+
+	binaryVersionsMu sync.Mutex
+	binaryVersions   map[string]string // Versions reported by `solc --version` keyed by the binary checksum.
+
+	downloads flightGroup  // In-flight binary downloads keyed by the destination path.
+	resyncs   atomic.Int64 // Number of re-syncs of a corrupt releases cache, telling lookups which triggered one.
 }
 
-// New initializes and returns a new instance of the Solc structure.
-func New(ctx context.Context, config *Config) (*Solc, error) {
-	if config == nil {
-		return nil, fmt.Errorf("config needs to be provided")
+// New initializes and returns a new instance of the Solc structure. The options are applied on top of the
+// provided *Config, or on top of the default configuration when none is provided, e.g.:
+//
+//	s, err := solc.New(ctx, config)
+//	s, err := solc.New(ctx, solc.WithReleasesPath(path), solc.WithOfflineMode())
+//
+// A provided *Config is used as is when no other option is passed. Otherwise the options are applied to a copy
+// of it, leaving the provided *Config untouched.
+func New(ctx context.Context, opts ...Option) (*Solc, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
 	}
 
+	config := o.config
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
 
-	return &Solc{
-		ctx:      ctx,
-		config:   config,
-		gOOSFunc: func() string { return runtime.GOOS },
-		client: &http.Client{
+	client := o.client
+	if client == nil {
+		client = &http.Client{
 			Timeout: config.GetHttpClientTimeout(),
-		},
-	}, nil
+		}
+	}
+
+	s := &Solc{
+		ctx:        ctx,
+		config:     config,
+		gOOSFunc:   func() string { return runtime.GOOS },
+		gOArchFunc: func() string { return runtime.GOARCH },
+		client:     client,
+		limiter:    newRateLimiter(config.GetRateLimit()),
+	}
+
+	// Restore the last sync time so that the sync throttling holds across process restarts.
+	s.loadSyncState()
+
+	return s, nil
 }
 
 // GetContext retrieves the context associated with the Solc instance.
@@ -59,7 +98,18 @@ func (s *Solc) GetHTTPClient() *http.Client {
 	return s.client
 }
 
-// Compile compiles the provided Solidity source code using the specified compiler configuration.
+// Close releases the resources held by the Solc instance. It closes the idle HTTP connections and removes the
+// working directories created by CompileSources. The instance shouldn't be used after Close.
+func (s *Solc) Close() error {
+	if s.client != nil {
+		s.client.CloseIdleConnections()
+	}
+
+	return s.Cleanup()
+}
+
+// Compile compiles the provided Solidity source code using the specified compiler configuration. Results with
+// warnings treated as errors, or partially recovered in error recovery mode, are returned along the error.
 func (s *Solc) Compile(ctx context.Context, source string, config *CompilerConfig) (*CompilerResults, error) {
 	compiler, err := NewCompiler(ctx, s, config, source)
 	if err != nil {
@@ -68,8 +118,149 @@ func (s *Solc) Compile(ctx context.Context, source string, config *CompilerConfi
 
 	compilerResults, err := compiler.Compile()
 	if err != nil {
+		// Warnings treated as errors, and partial artifacts recovered in error recovery mode, still come with the
+		// results for inspection.
+		if errors.Is(err, ErrCompilationWarnings) || (compilerResults != nil && compilerResults.IsPartial()) {
+			return compilerResults, err
+		}
 		return nil, err
 	}
 
 	return compilerResults, nil
 }
+
+// CompileEntry compiles the provided Solidity source code and returns only the entry contract result.
+// When the entry source name isn't set in the configuration, the last contract declared in the source is used.
+func (s *Solc) CompileEntry(ctx context.Context, source string, config *CompilerConfig) (*CompilerResult, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config needs to be provided")
+	}
+
+	if config.GetEntrySourceName() == "" {
+		if config.GetJsonConfig() != nil {
+			return nil, fmt.Errorf("entry source name must be provided when compiling with json config")
+		}
+
+		entry, err := inferEntryContract(source)
+		if err != nil {
+			return nil, err
+		}
+
+		// Do not mutate the caller's configuration.
+		entryConfig := *config
+		entryConfig.SetEntrySourceName(entry)
+		config = &entryConfig
+	}
+
+	compilerResults, err := s.Compile(ctx, source, config)
+	if err != nil {
+		return nil, err
+	}
+
+	entryContract := compilerResults.GetEntryContract()
+	if entryContract == nil {
+		return nil, fmt.Errorf("entry contract %s not found in compilation results", config.GetEntrySourceName())
+	}
+
+	return entryContract, nil
+}
+
+// CompileAcross compiles the provided Solidity source code with each of the provided compiler versions. Compilations
+// run concurrently, up to the provided concurrency limit, which defaults to the number of CPUs when not positive.
+// Results are keyed by the cleaned version. Errors of the individual compilations are joined together, along with
+// the results of the successful ones. Once the context is cancelled, pending compilations aren't started.
+func (s *Solc) CompileAcross(ctx context.Context, source string, versions []string, config *CompilerConfig, concurrency int) (map[string]*CompilerResults, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config needs to be provided")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]*CompilerResults, len(versions))
+		errs    = make(map[string]error)
+		jobs    = make(chan string)
+	)
+
+	for i := 0; i < concurrency && i < len(versions); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for version := range jobs {
+				// Do not mutate the caller's configuration, it's shared between the workers.
+				versionConfig := *config
+				versionConfig.SetCompilerVersion(version)
+
+				compilerResults, err := s.Compile(ctx, source, &versionConfig)
+
+				mu.Lock()
+				if err != nil {
+					errs[version] = err
+				} else {
+					results[version] = compilerResults
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, version := range versions {
+		version = getCleanedVersionTag(version)
+
+		select {
+		case <-ctx.Done():
+			mu.Lock()
+			errs[version] = ctx.Err()
+			mu.Unlock()
+			continue
+		default:
+		}
+
+		select {
+		case jobs <- version:
+		case <-ctx.Done():
+			mu.Lock()
+			errs[version] = ctx.Err()
+			mu.Unlock()
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	// Join the errors in the order of the provided versions so that the error is deterministic.
+	var joined []error
+	for _, version := range versions {
+		version = getCleanedVersionTag(version)
+		if err, ok := errs[version]; ok {
+			joined = append(joined, fmt.Errorf("version %s: %w", version, err))
+			delete(errs, version)
+		}
+	}
+
+	return results, errors.Join(joined...)
+}
+
+// inferEntryContract returns the name of the last non-abstract contract declared within the source.
+func inferEntryContract(source string) (string, error) {
+	decls, err := ExtractContractNames(source)
+	if err != nil {
+		return "", err
+	}
+
+	for i := len(decls) - 1; i >= 0; i-- {
+		if decls[i].Kind == ContractKindContract {
+			return decls[i].Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no contract declaration found to infer the entry contract from")
+}
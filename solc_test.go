@@ -5,6 +5,7 @@ import (
 	"os"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -112,3 +113,74 @@ func TestSolc(t *testing.T) {
 		})
 	}
 }
+
+func TestInferEntryContract(t *testing.T) {
+	entry, err := inferEntryContract(`
+		interface IStorage {}
+		contract Base {}
+		contract SimpleStorage is Base, IStorage {}
+		abstract contract Abstract {}
+		library Math {}
+	`)
+	assert.NoError(t, err)
+	assert.Equal(t, "SimpleStorage", entry)
+
+	_, err = inferEntryContract(`interface IStorage {}`)
+	assert.Error(t, err)
+}
+
+func TestCompileEntryErrors(t *testing.T) {
+	s := newTestSolc(t)
+
+	_, err := s.CompileEntry(context.TODO(), "contract A {}", nil)
+	assert.Error(t, err)
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "", &CompilerJsonConfig{})
+	assert.NoError(t, err)
+
+	_, err = s.CompileEntry(context.TODO(), "{}", config)
+	assert.Error(t, err)
+}
+
+func TestCompileAcross(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.19"}, Version{TagName: "v0.8.20"}, Version{TagName: "v0.8.21"})
+	for _, version := range []string{"0.8.19", "0.8.20", "0.8.21"} {
+		installFakeBinary(t, s, version, `sleep 0.3; echo '{"contracts": {"<stdin>:A": {"bin": "6080", "abi": []}}, "version": "`+version+`"}'`)
+	}
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	started := time.Now()
+	results, err := s.CompileAcross(context.TODO(), "contract A {}", []string{"v0.8.19", "0.8.20", "0.8.21", "0.8.22"}, config, 3)
+	assert.Less(t, time.Since(started), 900*time.Millisecond, "compilations must run concurrently")
+
+	assert.ErrorContains(t, err, "version 0.8.22")
+	assert.Len(t, results, 3)
+	for _, version := range []string{"0.8.19", "0.8.20", "0.8.21"} {
+		assert.Equal(t, version, results[version].GetResults()[0].GetCompilerVersion())
+	}
+
+	// The caller's configuration is left untouched.
+	assert.Equal(t, "0.8.20", config.GetCompilerVersion())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err = s.CompileAcross(ctx, "contract A {}", []string{"0.8.19", "0.8.20"}, config, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, results)
+}
+
+func TestClose(t *testing.T) {
+	s := newTestSolc(t)
+
+	dir, err := s.workDir("project")
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Close())
+
+	_, err = os.Stat(dir.path)
+	assert.True(t, os.IsNotExist(err))
+	assert.Empty(t, s.workDirs)
+}
@@ -0,0 +1,206 @@
+package solc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// BinarySource represents where the compiler of a given version is executed from.
+type BinarySource string
+
+const (
+	// BinarySourceNative denotes the native solc binary distributed in the GitHub releases.
+	BinarySourceNative BinarySource = "native"
+
+	// BinarySourceSolcJS denotes the emscripten soljson.js build executed via Node.
+	BinarySourceSolcJS BinarySource = "solcjs"
+)
+
+// solcJSAssetName is the name of the emscripten build asset attached to the GitHub releases.
+const solcJSAssetName = "soljson.js"
+
+// solcJSWrapperFilename is the name of the Node wrapper written next to the soljson builds.
+const solcJSWrapperFilename = "solcjs-wrapper.js"
+
+// solcJSWrapper is a small Node program loading the soljson build passed as first argument and exposing a subset
+// of the solc command line on top of it: --version, and the standard-json compilation of the input read from stdin.
+// Older builds only export the legacy compileStandard function.
+const solcJSWrapper = `'use strict';
+const soljson = require(process.argv[2]);
+const args = process.argv.slice(3);
+
+if (args.includes('--version')) {
+  const version = soljson.cwrap('_solidity_version' in soljson ? 'solidity_version' : 'version', 'string', [])();
+  process.stdout.write('solc, the solidity compiler commandline interface\nVersion: ' + version + '\n');
+  process.exit(0);
+}
+
+if (!args.includes('--standard-json')) {
+  process.stderr.write('solcjs only supports the --standard-json mode\n');
+  process.exit(1);
+}
+
+const name = '_solidity_compile' in soljson ? 'solidity_compile' : 'compileStandard';
+const compile = soljson.cwrap(name, 'string', ['string', 'number', 'number']);
+
+let input = '';
+process.stdin.setEncoding('utf8');
+process.stdin.on('data', (chunk) => { input += chunk; });
+process.stdin.on('end', () => { process.stdout.write(compile(input, 0, 0)); });
+`
+
+// SolcJSPath returns the path the soljson.js build of the specified version is, or would be, installed at.
+func (s *Solc) SolcJSPath(version string) string {
+	return filepath.Join(s.config.GetReleasesPath(), fmt.Sprintf("soljson-%s.js", getCleanedVersionTag(version)))
+}
+
+// GetBinarySource returns where the compiler of the specified version is executed from. It's the native binary
+// unless the solcjs fallback is enabled and no native binary is distributed for the version on this platform.
+func (s *Solc) GetBinarySource(version string) BinarySource {
+	if !s.config.IsSolcJSFallback() {
+		return BinarySourceNative
+	}
+
+	version, err := s.ResolveVersion(version)
+	if err != nil {
+		return BinarySourceNative
+	}
+
+	release, err := s.GetRelease(version)
+	if err != nil {
+		// Let the native lookup report the unknown version.
+		return BinarySourceNative
+	}
+
+	if s.hasNativeBinary(*release) {
+		return BinarySourceNative
+	}
+
+	return BinarySourceSolcJS
+}
+
+// hasNativeBinary reports whether a native binary of the release is distributed for the current platform.
+// Only the amd64 and, for recent releases, the arm64 architectures are published for linux.
+func (s *Solc) hasNativeBinary(release Version) bool {
+	if s.checkPlatformSupported() != nil {
+		return false
+	}
+
+	if arch := s.GetArchitecture(); s.GetDistribution() == Linux && arch != "amd64" && arch != "arm64" {
+		return false
+	}
+
+	distribution := s.GetDistributionForAsset()
+	for _, asset := range release.Assets {
+		if isAssetForDistribution(asset.Name, distribution) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkSyncSupported returns ErrUnsupportedPlatform if binaries can't be synced for the current platform.
+// With the solcjs fallback enabled every platform is supported as long as Node is available.
+func (s *Solc) checkSyncSupported() error {
+	if s.config.IsSolcJSFallback() {
+		return nil
+	}
+
+	return s.checkPlatformSupported()
+}
+
+// syncBinariesWithFallback downloads the native binaries of the specified versions when they're distributed for
+// the current platform, and the soljson.js builds of the remaining ones.
+func (s *Solc) syncBinariesWithFallback(ctx context.Context, versions []Version, limitVersion string) error {
+	limitVersion = getCleanedVersionTag(limitVersion)
+
+	var native []Version
+	for _, version := range versions {
+		versionTag := getCleanedVersionTag(version.TagName)
+		if limitVersion != "" && versionTag != limitVersion {
+			continue
+		}
+
+		if s.hasNativeBinary(version) {
+			native = append(native, version)
+			continue
+		}
+
+		if err := s.syncSolcJS(ctx, version); err != nil {
+			return err
+		}
+	}
+
+	if len(native) == 0 {
+		return nil
+	}
+
+	return s.syncBinariesFor(ctx, native, s.GetDistributionForAsset(), "")
+}
+
+// syncSolcJS downloads the soljson.js build of the release unless it's already installed.
+func (s *Solc) syncSolcJS(ctx context.Context, version Version) error {
+	versionTag := getCleanedVersionTag(version.TagName)
+	filename := s.SolcJSPath(versionTag)
+
+	if _, err := os.Stat(filename); err == nil {
+		return nil
+	}
+
+	for _, asset := range version.Assets {
+		if asset.Name != solcJSAssetName {
+			continue
+		}
+
+		s.syncLogger().Info(
+			"Downloading missing soljson release",
+			zap.String("version", versionTag),
+			zap.String("asset_local_filename", filepath.Base(filename)),
+		)
+
+		hooks := s.config.GetHooks()
+		hooks.downloadStart(versionTag)
+
+		started := time.Now()
+		if err := s.downloadFile(ctx, filename, versionTag, asset); err != nil {
+			hooks.downloadFail(versionTag, err)
+			return fmt.Errorf("error downloading soljson for version %s: %v", versionTag, err)
+		}
+		hooks.downloadComplete(versionTag, time.Since(started))
+
+		return nil
+	}
+
+	return fmt.Errorf("no native binary nor %s asset distributed for version %s", solcJSAssetName, versionTag)
+}
+
+// solcJSCommand returns the Node executable and the leading arguments running the soljson.js build of the
+// specified version through the wrapper, which is (re)written into the releases path when missing or outdated.
+func (s *Solc) solcJSCommand(version string) (string, []string, error) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		return "", nil, fmt.Errorf("solcjs fallback requires node on PATH: %w", err)
+	}
+
+	soljsonPath := s.SolcJSPath(version)
+	if _, err := os.Stat(soljsonPath); err != nil {
+		return "", nil, fmt.Errorf("soljson for version %s not found", getCleanedVersionTag(version))
+	}
+
+	wrapperPath := filepath.Join(s.config.GetReleasesPath(), solcJSWrapperFilename)
+	if current, err := os.ReadFile(wrapperPath); err != nil || !bytes.Equal(current, []byte(solcJSWrapper)) {
+		if err := writeFileAtomic(wrapperPath, []byte(solcJSWrapper), 0600); err != nil {
+			return "", nil, fmt.Errorf("failed to write solcjs wrapper: %w", err)
+		}
+	}
+
+	return node, []string{wrapperPath, soljsonPath}, nil
+}
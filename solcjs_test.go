@@ -0,0 +1,108 @@
+package solc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBinarySource(t *testing.T) {
+	s := newTestSolc(t,
+		Version{TagName: "v0.8.20", Assets: []Asset{{Name: "solc-static-linux"}, {Name: solcJSAssetName}}},
+		Version{TagName: "v0.4.10", Assets: []Asset{{Name: solcJSAssetName}}},
+	)
+	s.gOOSFunc = func() string { return "linux" }
+	s.gOArchFunc = func() string { return "amd64" }
+
+	assert.Equal(t, BinarySourceNative, s.GetBinarySource("0.4.10"), "fallback disabled")
+
+	s.config.SetSolcJSFallback(true)
+	assert.Equal(t, BinarySourceNative, s.GetBinarySource("0.8.20"))
+	assert.Equal(t, BinarySourceSolcJS, s.GetBinarySource("v0.4.10"))
+	assert.Equal(t, BinarySourceNative, s.GetBinarySource("0.1.0"), "unknown versions are left to the native lookup")
+
+	s.gOArchFunc = func() string { return "arm64" }
+	assert.Equal(t, BinarySourceSolcJS, s.GetBinarySource("0.8.20"))
+
+	// Recent releases ship a native arm64 build for linux.
+	s.localReleases[0].Assets = append(s.localReleases[0].Assets, Asset{Name: "solc-static-linux-arm64"})
+	assert.Equal(t, BinarySourceNative, s.GetBinarySource("0.8.20"))
+
+	s.gOOSFunc = func() string { return "freebsd" }
+	assert.Equal(t, BinarySourceSolcJS, s.GetBinarySource("0.8.20"))
+}
+
+func TestSyncBinariesSolcJSFallback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("var Module = {};"))
+	}))
+	defer server.Close()
+
+	versions := []Version{{TagName: "v0.8.20", Assets: []Asset{{Name: solcJSAssetName, BrowserDownloadURL: server.URL}}}}
+
+	s := newTestSolc(t, versions...)
+	s.gOOSFunc = func() string { return "freebsd" }
+	assert.ErrorIs(t, s.SyncBinaries(versions, ""), ErrUnsupportedPlatform)
+
+	s.config.SetSolcJSFallback(true)
+	assert.NoError(t, s.SyncBinaries(versions, ""))
+
+	data, err := os.ReadFile(s.SolcJSPath("0.8.20"))
+	assert.NoError(t, err)
+	assert.Equal(t, "var Module = {};", string(data))
+
+	assert.ErrorContains(t, s.SyncBinaries([]Version{{TagName: "v0.4.10"}}, ""), "no native binary nor soljson.js asset")
+}
+
+func TestCompileSolcJSFallback(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake node executables are shell scripts and are not supported on windows")
+	}
+
+	s := newTestSolc(t, Version{TagName: "v0.8.20", Assets: []Asset{{Name: solcJSAssetName}}})
+	s.config.SetSolcJSFallback(true)
+	assert.NoError(t, os.WriteFile(s.SolcJSPath("0.8.20"), []byte("var Module = {};"), 0600))
+
+	// The fake node checks it's given the wrapper and the soljson build before the solc arguments.
+	binDir := t.TempDir()
+	script := `#!/bin/sh
+[ "$(basename "$1")" = "solcjs-wrapper.js" ] && [ "$(basename "$2")" = "soljson-0.8.20.js" ] && [ "$3" = "--standard-json" ] || exit 1
+cat > /dev/null
+echo '{"contracts": {"A.sol": {"A": {"abi": [], "evm": {"bytecode": {"object": "6080"}}}}}, "version": "0.8.20+commit.a1b79de6"}'
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(binDir, "node"), []byte(script), 0700)) // #nosec G306
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	jsonConfig := &CompilerJsonConfig{
+		Language: "Solidity",
+		Sources:  map[string]Source{"A.sol": {Content: "contract A {}"}},
+		Settings: Settings{OutputSelection: DefaultOutputSelection()},
+	}
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "A", jsonConfig)
+	assert.NoError(t, err)
+
+	input, err := jsonConfig.ToJSON()
+	assert.NoError(t, err)
+
+	results, err := s.Compile(context.TODO(), string(input), config)
+	assert.NoError(t, err)
+	if assert.NotNil(t, results) {
+		assert.Equal(t, "6080", results.GetEntryContract().GetBytecode())
+	}
+
+	wrapper, err := os.ReadFile(filepath.Join(s.config.GetReleasesPath(), solcJSWrapperFilename))
+	assert.NoError(t, err)
+	assert.Equal(t, solcJSWrapper, string(wrapper))
+
+	simpleConfig, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+	_, err = s.Compile(context.TODO(), "contract A {}", simpleConfig)
+	assert.ErrorContains(t, err, "requires a json config")
+}
@@ -0,0 +1,152 @@
+package solc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ContractKind represents the kind of a Solidity contract declaration.
+type ContractKind string
+
+// String returns the string representation of the ContractKind.
+func (k ContractKind) String() string {
+	return string(k)
+}
+
+const (
+	// ContractKindContract denotes a regular contract declaration.
+	ContractKindContract ContractKind = "contract"
+
+	// ContractKindAbstract denotes an abstract contract declaration.
+	ContractKindAbstract ContractKind = "abstract contract"
+
+	// ContractKindLibrary denotes a library declaration.
+	ContractKindLibrary ContractKind = "library"
+
+	// ContractKindInterface denotes an interface declaration.
+	ContractKindInterface ContractKind = "interface"
+)
+
+// ContractDecl represents a contract, library or interface declared within a Solidity source.
+type ContractDecl struct {
+	Name string       `json:"name"` // The name of the declared contract.
+	Kind ContractKind `json:"kind"` // The kind of the declaration.
+}
+
+// contractDeclRegex matches contract, abstract contract, library and interface declarations.
+var contractDeclRegex = regexp.MustCompile(`\b(abstract\s+contract|contract|library|interface)\s+([A-Za-z_$][A-Za-z0-9_$]*)`)
+
+// blankStrings replaces the content of string literals with spaces so that keywords within strings aren't matched.
+// The length of the source is preserved.
+func blankStrings(source string) string {
+	out := []byte(source)
+
+	for i := 0; i < len(out); i++ {
+		quote := out[i]
+		if quote != '"' && quote != '\'' {
+			continue
+		}
+
+		for i++; i < len(out) && out[i] != quote && out[i] != '\n'; i++ {
+			if out[i] == '\\' && i+1 < len(out) {
+				out[i] = ' '
+				i++
+			}
+			out[i] = ' '
+		}
+	}
+
+	return string(out)
+}
+
+// ExtractContractNames scans the provided Solidity source and returns all the declared contracts, libraries and
+// interfaces in the order of their declaration. Declarations within comments and strings are ignored.
+func ExtractContractNames(source string) ([]ContractDecl, error) {
+	if strings.TrimSpace(source) == "" {
+		return nil, fmt.Errorf("source code must be provided to extract contract names")
+	}
+
+	var decls []ContractDecl
+	for _, match := range contractDeclRegex.FindAllStringSubmatch(blankStrings(stripComments(source)), -1) {
+		kind := ContractKind(strings.Join(strings.Fields(match[1]), " "))
+		decls = append(decls, ContractDecl{Name: match[2], Kind: kind})
+	}
+
+	return decls, nil
+}
+
+// licenseRegex matches the SPDX license identifier comment as recognized by solc.
+var licenseRegex = regexp.MustCompile(`SPDX-License-Identifier:\s*([^\r\n*]+)`)
+
+// spdxLicenses is the list of SPDX license identifiers recognized by ValidateLicense. It covers the licenses
+// commonly found in Solidity projects along with "UNLICENSED", which solc accepts for proprietary code.
+var spdxLicenses = map[string]bool{
+	"UNLICENSED": true, "0BSD": true, "AFL-3.0": true, "AGPL-1.0-only": true, "AGPL-1.0-or-later": true,
+	"AGPL-3.0": true, "AGPL-3.0-only": true, "AGPL-3.0-or-later": true, "Apache-1.0": true, "Apache-1.1": true,
+	"Apache-2.0": true, "APSL-2.0": true, "Artistic-2.0": true, "BlueOak-1.0.0": true, "BSD-1-Clause": true,
+	"BSD-2-Clause": true, "BSD-2-Clause-Patent": true, "BSD-3-Clause": true, "BSD-3-Clause-Clear": true,
+	"BSD-4-Clause": true, "BSL-1.0": true, "BUSL-1.1": true, "CAL-1.0": true, "CC-BY-4.0": true,
+	"CC-BY-NC-4.0": true, "CC-BY-NC-SA-4.0": true, "CC-BY-SA-4.0": true, "CC0-1.0": true, "CDDL-1.0": true,
+	"CECILL-2.1": true, "ECL-2.0": true, "EPL-1.0": true, "EPL-2.0": true, "EUPL-1.1": true, "EUPL-1.2": true,
+	"GPL-2.0": true, "GPL-2.0-only": true, "GPL-2.0-or-later": true, "GPL-3.0": true, "GPL-3.0-only": true,
+	"GPL-3.0-or-later": true, "ISC": true, "LGPL-2.0-only": true, "LGPL-2.0-or-later": true, "LGPL-2.1": true,
+	"LGPL-2.1-only": true, "LGPL-2.1-or-later": true, "LGPL-3.0": true, "LGPL-3.0-only": true,
+	"LGPL-3.0-or-later": true, "LPPL-1.3c": true, "MIT": true, "MIT-0": true, "MPL-1.1": true, "MPL-2.0": true,
+	"MPL-2.0-no-copyleft-exception": true, "MS-PL": true, "MS-RL": true, "MulanPSL-2.0": true, "NCSA": true,
+	"ODbL-1.0": true, "OFL-1.1": true, "OSL-3.0": true, "PostgreSQL": true, "Unlicense": true, "UPL-1.0": true,
+	"Vim": true, "WTFPL": true, "X11": true, "Zlib": true, "ZPL-2.1": true,
+}
+
+// ExtractLicense returns the SPDX license identifier expression declared within the provided Solidity source.
+// The second return value is false when the source doesn't declare a license.
+func ExtractLicense(source string) (string, bool) {
+	match := licenseRegex.FindStringSubmatch(source)
+	if match == nil {
+		return "", false
+	}
+
+	license := strings.TrimSpace(match[1])
+	return license, license != ""
+}
+
+// ValidateLicense checks that the provided SPDX license expression only references known SPDX license identifiers.
+// Compound expressions using AND, OR, WITH and parentheses are supported; exceptions following WITH aren't checked.
+func ValidateLicense(license string) error {
+	tokens := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(license))
+	if len(tokens) == 0 {
+		return fmt.Errorf("license must be provided")
+	}
+
+	expectLicense := true
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		if !expectLicense {
+			switch token {
+			case "AND", "OR":
+				expectLicense = true
+			case "WITH":
+				// Skip the exception identifier.
+				i++
+				if i >= len(tokens) {
+					return fmt.Errorf("invalid license expression: %s", license)
+				}
+			default:
+				return fmt.Errorf("invalid license expression: %s", license)
+			}
+			continue
+		}
+
+		if !spdxLicenses[strings.TrimSuffix(token, "+")] {
+			return fmt.Errorf("unknown SPDX license identifier: %s", token)
+		}
+		expectLicense = false
+	}
+
+	if expectLicense {
+		return fmt.Errorf("invalid license expression: %s", license)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package solc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractContractNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected []ContractDecl
+		wantErr  bool
+	}{
+		{
+			name: "All Declaration Kinds",
+			source: `// SPDX-License-Identifier: MIT
+			pragma solidity ^0.8.0;
+
+			interface IStorage {
+				function get() external view returns (uint256);
+			}
+
+			library Math {}
+
+			abstract   contract Base {}
+
+			contract SimpleStorage is Base, IStorage {
+				function get() external pure returns (uint256) { return 1; }
+			}`,
+			expected: []ContractDecl{
+				{Name: "IStorage", Kind: ContractKindInterface},
+				{Name: "Math", Kind: ContractKindLibrary},
+				{Name: "Base", Kind: ContractKindAbstract},
+				{Name: "SimpleStorage", Kind: ContractKindContract},
+			},
+		},
+		{
+			name: "Ignores Comments And Strings",
+			source: `// contract Commented {}
+			/* library Block {} */
+			contract Real {
+				string constant a = "contract InString {}";
+				string constant b = 'interface \'Escaped {}';
+			}`,
+			expected: []ContractDecl{
+				{Name: "Real", Kind: ContractKindContract},
+			},
+		},
+		{
+			name:    "Empty Source",
+			source:  "  ",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decls, err := ExtractContractNames(tt.source)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, decls)
+		})
+	}
+}
+
+func TestExtractLicense(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+		found    bool
+	}{
+		{
+			name:     "Single Line Comment",
+			source:   "// SPDX-License-Identifier: MIT\npragma solidity ^0.8.0;",
+			expected: "MIT",
+			found:    true,
+		},
+		{
+			name:     "Block Comment",
+			source:   "/* SPDX-License-Identifier: GPL-3.0-or-later */\npragma solidity ^0.8.0;",
+			expected: "GPL-3.0-or-later",
+			found:    true,
+		},
+		{
+			name:     "Compound Expression",
+			source:   "// SPDX-License-Identifier: MIT OR Apache-2.0\r\ncontract A {}",
+			expected: "MIT OR Apache-2.0",
+			found:    true,
+		},
+		{
+			name:   "Missing License",
+			source: "pragma solidity ^0.8.0;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			license, found := ExtractLicense(tt.source)
+			assert.Equal(t, tt.expected, license)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
+
+func TestValidateLicense(t *testing.T) {
+	tests := []struct {
+		license string
+		wantErr bool
+	}{
+		{license: "MIT"},
+		{license: "UNLICENSED"},
+		{license: "MIT OR Apache-2.0"},
+		{license: "(MIT AND BSD-3-Clause) OR GPL-2.0+"},
+		{license: "GPL-2.0-or-later WITH Classpath-exception-2.0"},
+		{license: "", wantErr: true},
+		{license: "NotALicense", wantErr: true},
+		{license: "MIT OR", wantErr: true},
+		{license: "MIT Apache-2.0", wantErr: true},
+		{license: "MIT WITH", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.license, func(t *testing.T) {
+			err := ValidateLicense(tt.license)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
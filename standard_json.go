@@ -0,0 +1,141 @@
+package solc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// StandardJSONOutput represents the native standard-json output of the solc compiler. Parts of the output whose
+// shape differs between solc versions (e.g. the AST) are kept as raw JSON so that they round-trip unchanged.
+type StandardJSONOutput struct {
+	Errors    []StandardJSONError                        `json:"errors,omitempty"`    // The errors and warnings reported by solc.
+	Sources   map[string]StandardJSONSource              `json:"sources,omitempty"`   // The per-source outputs keyed by source unit name.
+	Contracts map[string]map[string]StandardJSONContract `json:"contracts,omitempty"` // The per-contract outputs keyed by source unit name and contract name.
+}
+
+// HasErrors returns true if solc reported at least one error, as opposed to warnings and infos.
+func (o *StandardJSONOutput) HasErrors() bool {
+	for _, err := range o.Errors {
+		if err.Severity == "error" {
+			return true
+		}
+	}
+	return false
+}
+
+// StandardJSONError represents an error or warning within the standard-json output.
+type StandardJSONError struct {
+	SourceLocation           *StandardJSONSourceLocation  `json:"sourceLocation,omitempty"`           // The location of the error. Optional.
+	SecondarySourceLocations []StandardJSONSourceLocation `json:"secondarySourceLocations,omitempty"` // Further locations related to the error. Optional.
+	Type                     string                       `json:"type"`                               // The error type (e.g., "TypeError").
+	Component                string                       `json:"component"`                          // The component the error originates from (e.g., "general").
+	Severity                 string                       `json:"severity"`                           // The severity: "error", "warning" or "info".
+	ErrorCode                string                       `json:"errorCode,omitempty"`                // The unique error code. Optional.
+	Message                  string                       `json:"message"`                            // The error message.
+	FormattedMessage         string                       `json:"formattedMessage,omitempty"`         // The message formatted with the source location. Optional.
+}
+
+// StandardJSONSourceLocation represents a location within a source unit.
+type StandardJSONSourceLocation struct {
+	File    string `json:"file"`              // The source unit name.
+	Start   int    `json:"start"`             // The start byte offset.
+	End     int    `json:"end"`               // The end byte offset.
+	Message string `json:"message,omitempty"` // The message attached to secondary locations. Optional.
+}
+
+// StandardJSONSource represents the output for a single source unit.
+type StandardJSONSource struct {
+	ID  int             `json:"id"`            // The source identifier as used in source maps.
+	AST json.RawMessage `json:"ast,omitempty"` // The AST of the source unit, if selected.
+}
+
+// StandardJSONContract represents the output for a single contract.
+type StandardJSONContract struct {
+	ABI           json.RawMessage  `json:"abi,omitempty"`           // The contract ABI.
+	Metadata      string           `json:"metadata,omitempty"`      // The contract metadata as a JSON encoded string.
+	UserDoc       json.RawMessage  `json:"userdoc,omitempty"`       // The user documentation (NatSpec).
+	DevDoc        json.RawMessage  `json:"devdoc,omitempty"`        // The developer documentation (NatSpec).
+	IR            string           `json:"ir,omitempty"`            // The intermediate representation before optimization.
+	IROptimized   string           `json:"irOptimized,omitempty"`   // The intermediate representation after optimization.
+	StorageLayout json.RawMessage  `json:"storageLayout,omitempty"` // The storage layout of the contract.
+	EVM           *StandardJSONEVM `json:"evm,omitempty"`           // The EVM related outputs.
+}
+
+// StandardJSONEVM represents the EVM related outputs of a contract.
+type StandardJSONEVM struct {
+	Assembly          string                `json:"assembly,omitempty"`          // The assembly in text form.
+	LegacyAssembly    json.RawMessage       `json:"legacyAssembly,omitempty"`    // The assembly in JSON form.
+	Bytecode          *StandardJSONBytecode `json:"bytecode,omitempty"`          // The creation bytecode.
+	DeployedBytecode  *StandardJSONBytecode `json:"deployedBytecode,omitempty"`  // The deployed (runtime) bytecode.
+	MethodIdentifiers map[string]string     `json:"methodIdentifiers,omitempty"` // The function signatures mapped to selectors.
+	GasEstimates      json.RawMessage       `json:"gasEstimates,omitempty"`      // The gas estimates.
+}
+
+// StandardJSONBytecode represents the creation or deployed bytecode of a contract.
+type StandardJSONBytecode struct {
+	FunctionDebugData   json.RawMessage                       `json:"functionDebugData,omitempty"`   // The debugging data at the function level.
+	Object              string                                `json:"object"`                        // The hex encoded bytecode.
+	Opcodes             string                                `json:"opcodes,omitempty"`             // The opcodes list.
+	SourceMap           string                                `json:"sourceMap,omitempty"`           // The source mapping.
+	GeneratedSources    json.RawMessage                       `json:"generatedSources,omitempty"`    // The compiler generated sources.
+	LinkReferences      map[string]map[string][]LinkReference `json:"linkReferences,omitempty"`      // The library placeholders keyed by source and library name.
+	ImmutableReferences map[string][]ImmutableReference       `json:"immutableReferences,omitempty"` // The immutable positions keyed by AST ID. Deployed bytecode only.
+}
+
+// LinkReference represents the byte offsets of a library address placeholder within the bytecode.
+type LinkReference struct {
+	Start  int `json:"start"`  // The byte offset of the placeholder.
+	Length int `json:"length"` // The length of the placeholder in bytes.
+}
+
+// CompileStandardJSON compiles the provided standard-json input and returns the native standard-json output. The
+// compiler version and the options, such as the maximum compile duration or the binary path, are taken from the
+// configuration, which is run in standard-json mode with the input as its json config. The compilation goes through
+// the same pipeline as Compile, including the hooks, the binary repair and the solcjs fallback. Compilation errors
+// are reported within the output rather than as an error, the returned error is only set when solc couldn't be run
+// or its output couldn't be parsed, or along the output when warnings are treated as errors.
+func (s *Solc) CompileStandardJSON(ctx context.Context, input []byte, config *CompilerConfig) (*StandardJSONOutput, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config must be provided to compile standard-json input")
+	}
+
+	var jsonConfig CompilerJsonConfig
+	if err := json.Unmarshal(input, &jsonConfig); err != nil {
+		return nil, fmt.Errorf("standard-json input must be valid json: %w", err)
+	}
+
+	// Do not mutate the caller's configuration.
+	jsonModeConfig := *config
+	jsonModeConfig.JsonConfig = &jsonConfig
+	jsonModeConfig.KeepRawOutput = true
+	if !containsArgument(jsonModeConfig.Arguments, "--standard-json") {
+		jsonModeConfig.Arguments = append([]string{"--standard-json"}, jsonModeConfig.Arguments...)
+	}
+
+	compiler, err := NewCompiler(ctx, s, &jsonModeConfig, string(input))
+	if err != nil {
+		return nil, err
+	}
+
+	results, compileErr := compiler.CompileWithContext(ctx)
+	if results == nil || results.GetRawOutput() == nil {
+		if compileErr == nil {
+			compileErr = fmt.Errorf("solc produced no output")
+		}
+
+		var execErr *CompileExecError
+		if errors.As(compileErr, &execErr) {
+			return nil, fmt.Errorf("failed to compile standard-json input: %w: %s", compileErr, execErr.Stderr)
+		}
+		return nil, fmt.Errorf("failed to compile standard-json input: %w", compileErr)
+	}
+
+	var output StandardJSONOutput
+	if err := json.Unmarshal(results.GetRawOutput(), &output); err != nil {
+		return nil, fmt.Errorf("failed to parse standard-json output: %w", err)
+	}
+
+	return &output, compileErr
+}
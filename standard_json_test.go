@@ -0,0 +1,126 @@
+package solc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const standardJSONOutputFixture = `{
+	"errors": [
+		{
+			"component": "general",
+			"errorCode": "2072",
+			"formattedMessage": "Warning: Unused local variable.",
+			"message": "Unused local variable.",
+			"severity": "warning",
+			"sourceLocation": {"end": 90, "file": "A.sol", "start": 84},
+			"type": "Warning"
+		}
+	],
+	"sources": {
+		"A.sol": {"ast": {"absolutePath": "A.sol", "id": 7, "nodeType": "SourceUnit"}, "id": 0}
+	},
+	"contracts": {
+		"A.sol": {
+			"A": {
+				"abi": [{"inputs": [], "name": "f", "outputs": [], "stateMutability": "pure", "type": "function"}],
+				"evm": {
+					"bytecode": {
+						"object": "6080__$a2b3$__",
+						"linkReferences": {"L.sol": {"L": [{"length": 20, "start": 2}]}}
+					},
+					"deployedBytecode": {
+						"object": "6080",
+						"immutableReferences": {"3": [{"length": 32, "start": 10}]}
+					},
+					"methodIdentifiers": {"f()": "26121ff0"}
+				}
+			}
+		}
+	}
+}`
+
+func TestCompileStandardJSON(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `[ "$1" = "--standard-json" ] || exit 1
+cat > /dev/null
+cat <<'EOF'
+`+standardJSONOutputFixture+`
+EOF`)
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "", nil)
+	assert.NoError(t, err)
+
+	_, err = s.CompileStandardJSON(context.TODO(), []byte("not json"), config)
+	assert.Error(t, err)
+
+	_, err = s.CompileStandardJSON(context.TODO(), []byte(`{}`), nil)
+	assert.Error(t, err)
+
+	output, err := s.CompileStandardJSON(context.TODO(), []byte(`{"language": "Solidity", "sources": {}}`), config)
+	assert.NoError(t, err)
+	assert.Nil(t, config.GetJsonConfig(), "the provided config isn't mutated")
+
+	assert.False(t, output.HasErrors())
+	assert.Len(t, output.Errors, 1)
+	assert.Equal(t, "2072", output.Errors[0].ErrorCode)
+	assert.Equal(t, "A.sol", output.Errors[0].SourceLocation.File)
+
+	assert.Equal(t, 0, output.Sources["A.sol"].ID)
+	assert.JSONEq(t, `{"absolutePath": "A.sol", "id": 7, "nodeType": "SourceUnit"}`, string(output.Sources["A.sol"].AST))
+
+	contract := output.Contracts["A.sol"]["A"]
+	assert.NotNil(t, contract.EVM)
+	assert.Equal(t, "26121ff0", contract.EVM.MethodIdentifiers["f()"])
+	assert.Equal(t, []LinkReference{{Start: 2, Length: 20}}, contract.EVM.Bytecode.LinkReferences["L.sol"]["L"])
+	assert.Equal(t, []ImmutableReference{{Start: 10, Length: 32}}, contract.EVM.DeployedBytecode.ImmutableReferences["3"])
+
+	roundTrip, err := json.Marshal(output)
+	assert.NoError(t, err)
+	assert.JSONEq(t, standardJSONOutputFixture, string(roundTrip))
+}
+
+func TestCompileStandardJSONFailure(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `echo "fatal" >&2; exit 1`)
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "", nil)
+	assert.NoError(t, err)
+
+	_, err = s.CompileStandardJSON(context.TODO(), []byte(`{}`), config)
+	assert.ErrorContains(t, err, "fatal")
+
+	var execErr *CompileExecError
+	if assert.ErrorAs(t, err, &execErr) {
+		assert.Equal(t, 1, execErr.ExitCode)
+	}
+
+	config.SetCompilerVersion("0.8.21")
+	_, err = s.CompileStandardJSON(context.TODO(), []byte(`{}`), config)
+	assert.Error(t, err)
+}
+
+func TestCompileStandardJSONSharedPipeline(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `exec sleep 5`)
+
+	var events []string
+	s.config.SetHooks(Hooks{
+		OnCompileStart: func(version string) { events = append(events, "start:"+version) },
+		OnCompileEnd:   func(version string, duration time.Duration, err error) { events = append(events, "end:"+version) },
+	})
+
+	config, err := NewCompilerConfigFromJSON("0.8.20", "", nil)
+	assert.NoError(t, err)
+	config.SetMaxCompileDuration(100 * time.Millisecond)
+
+	started := time.Now()
+	_, err = s.CompileStandardJSON(context.TODO(), []byte(`{"language": "Solidity", "sources": {}}`), config)
+	assert.ErrorIs(t, err, ErrCompileTimeout)
+	assert.Less(t, time.Since(started), 5*time.Second)
+	assert.Equal(t, []string{"start:0.8.20", "end:0.8.20"}, events)
+}
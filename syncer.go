@@ -1,8 +1,10 @@
 package solc
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -10,59 +12,266 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
+const (
+	// SyncSourceNetwork indicates that the cached releases were fetched from GitHub.
+	SyncSourceNetwork = "network"
+
+	// SyncSourceDisk indicates that the cached releases were loaded from the local releases.json file.
+	SyncSourceDisk = "disk"
+)
+
+// SyncStatus represents the metadata about the last releases synchronization.
+type SyncStatus struct {
+	LastSync     time.Time `json:"last_sync"`     // The last time releases were synced from the network.
+	VersionCount int       `json:"version_count"` // The number of versions in the local cache.
+	Source       string    `json:"source"`        // Where the cached releases came from (network or disk).
+	ETag         string    `json:"etag"`          // The ETag of the first releases page returned by GitHub.
+}
+
+// GetSyncStatus returns the metadata about the last releases synchronization.
+func (s *Solc) GetSyncStatus() SyncStatus {
+	return SyncStatus{
+		LastSync:     s.lastSync,
+		VersionCount: len(s.localReleases),
+		Source:       s.syncSource,
+		ETag:         s.etag,
+	}
+}
+
+// syncState is the metadata of the last releases synchronization persisted next to releases.json, so that the
+// sync throttling holds across process restarts.
+type syncState struct {
+	LastSync time.Time `json:"last_sync"`
+	ETag     string    `json:"etag"`
+}
+
+// GetSyncStatePath returns the path to the file persisting the metadata of the last releases synchronization.
+func (s *Solc) GetSyncStatePath() string {
+	return filepath.Join(s.config.GetReleasesPath(), "releases.sync.json")
+}
+
+// loadSyncState restores the metadata of the last releases synchronization persisted by a previous process. A missing
+// or unreadable state is ignored, resulting in a regular sync. An older state never overrides a newer one.
+func (s *Solc) loadSyncState() {
+	data, err := os.ReadFile(s.GetSyncStatePath())
+	if err != nil {
+		return
+	}
+
+	var state syncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		s.config.GetLogger().Warn(
+			"Ignoring corrupt releases sync state",
+			zap.String("path", s.GetSyncStatePath()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if state.LastSync.After(s.lastSync) {
+		s.lastSync = state.LastSync
+		s.etag = state.ETag
+	}
+}
+
+// saveSyncState persists the metadata of the last releases synchronization.
+func (s *Solc) saveSyncState() error {
+	data, err := json.Marshal(syncState{LastSync: s.lastSync, ETag: s.etag})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.GetSyncStatePath(), data, 0600)
+}
+
 // SyncReleases fetches the available Solidity versions from GitHub, saves them to releases.json, and reloads the local cache.
 func (s *Solc) SyncReleases() ([]Version, error) {
-	var allVersions []Version
-	page := 1
+	return s.syncReleases(s.ctx)
+}
+
+// syncReleases fetches the available Solidity versions like SyncReleases, under the provided context.
+func (s *Solc) syncReleases(ctx context.Context) ([]Version, error) {
+	if s.config.IsOffline() {
+		return nil, ErrOffline
+	}
 
 	// Sync maximum 4 times per day in order to increase the speed of the sync process when there's really
 	// no need to sync more often than that.
 	if time.Since(s.lastSync) < time.Duration(6*time.Hour) {
+		// The last sync may have been done by a previous process, in which case releases aren't loaded yet.
+		if s.localReleases == nil {
+			return s.GetLocalReleases()
+		}
 		return s.localReleases, nil
 	}
 
+	hooks := s.config.GetHooks()
+	hooks.syncStart()
+
+	started := time.Now()
+	versions, err := s.fetchReleases(ctx)
+	hooks.syncEnd(len(versions), time.Since(started), err)
+
+	return versions, err
+}
+
+// SyncReleasesSince fetches the Solidity versions released after the provided tag from GitHub, merges them into
+// the cached releases, saves them to releases.json, and reloads the local cache. Releases are assumed to be
+// listed newest first, so pagination stops at the page holding the tag, which is usually the first one. The tag
+// has to be cached; an empty tag stands for the newest cached release. Without any cached releases, all the
+// releases are fetched. Unlike SyncReleases it isn't throttled.
+func (s *Solc) SyncReleasesSince(tag string) ([]Version, error) {
+	if s.config.IsOffline() {
+		return nil, ErrOffline
+	}
+
+	cached := s.GetCachedReleases()
+	if cached == nil {
+		// A missing or corrupt cache simply results in fetching all the releases.
+		cached, _ = s.GetLocalReleases()
+	}
+
+	tag = getCleanedVersionTag(tag)
+	if tag == "" && len(cached) > 0 {
+		tag = getCleanedVersionTag(cached[0].TagName)
+	}
+
+	if tag != "" {
+		found := false
+		for _, version := range cached {
+			if getCleanedVersionTag(version.TagName) == tag {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("release %s is not cached, a full releases sync is required", tag)
+		}
+	}
+
+	hooks := s.config.GetHooks()
+	hooks.syncStart()
+
+	started := time.Now()
+	versions, err := s.fetchReleasesSince(s.ctx, tag, cached)
+	hooks.syncEnd(len(versions), time.Since(started), err)
+
+	return versions, err
+}
+
+// fetchReleasesSince fetches the release pages from GitHub until the provided tag is listed, merges the newer
+// releases with the cached ones, saves them to releases.json and reloads the local cache.
+func (s *Solc) fetchReleasesSince(ctx context.Context, tag string, cached []Version) ([]Version, error) {
+	newer, etag, stopped, err := s.fetchReleasePages(ctx, func(version Version) bool {
+		return getCleanedVersionTag(version.TagName) == tag
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The tag wasn't listed, so the fetched releases are the whole history on their own.
+	if !stopped {
+		return s.saveReleases(newer, etag)
+	}
+
+	seen := make(map[string]bool, len(newer))
+	for _, version := range newer {
+		seen[getCleanedVersionTag(version.TagName)] = true
+	}
+
+	merged := newer
+	for _, version := range cached {
+		if !seen[getCleanedVersionTag(version.TagName)] {
+			merged = append(merged, version)
+		}
+	}
+
+	return s.saveReleases(merged, etag)
+}
+
+// fetchReleases fetches all the release pages from GitHub, saves them to releases.json and reloads the local cache.
+func (s *Solc) fetchReleases(ctx context.Context) ([]Version, error) {
+	allVersions, etag, _, err := s.fetchReleasePages(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveReleases(allVersions, etag)
+}
+
+// fetchReleasePages fetches the release pages from GitHub until an empty page is returned, or until the stop
+// function, if provided, matches a release. Releases from the matching one onwards are left out. It returns the
+// fetched releases, the ETag of the first page, and whether the stop function matched.
+func (s *Solc) fetchReleasePages(ctx context.Context, stop func(version Version) bool) ([]Version, string, bool, error) {
+	var allVersions []Version
+	var etag string
+	page := 1
+
 	for {
-		url := fmt.Sprintf("%s?page=%d", s.config.GetReleasesUrl(), page)
+		// Stop paginating as soon as the context is cancelled instead of starting yet another request.
+		select {
+		case <-ctx.Done():
+			return nil, "", false, ctx.Err()
+		default:
+		}
+
+		url := fmt.Sprintf("%s?page=%d&per_page=%d", s.config.GetReleasesUrl(), page, s.config.GetReleasesPerPage())
 		req, err := http.NewRequest("GET", url, nil)
 		if err != nil {
-			return nil, err
+			return nil, "", false, err
 		}
 
-		req.Header.Add("Authorization", fmt.Sprintf("token %s", s.config.personalAccessToken))
-		req = req.WithContext(s.ctx)
+		// Unauthenticated access is rate limited by GitHub but still functional, so we only send the
+		// Authorization header when the token is actually configured.
+		if s.config.personalAccessToken != "" {
+			req.Header.Add("Authorization", fmt.Sprintf("token %s", s.config.personalAccessToken))
+		}
+		req = req.WithContext(ctx)
+
+		if err := s.waitRateLimit(ctx); err != nil {
+			return nil, "", false, err
+		}
 
 		resp, err := s.GetHTTPClient().Do(req)
 		if err != nil {
-			return nil, err
+			return nil, "", false, err
+		}
+
+		if page == 1 {
+			etag = resp.Header.Get("ETag")
 		}
 
 		bodyBytes, err := io.ReadAll(resp.Body)
 		if err != nil {
 			if err := resp.Body.Close(); err != nil {
-				return nil, err
+				return nil, "", false, err
 			}
-			return nil, err
+			return nil, "", false, err
 		}
 
 		if err := resp.Body.Close(); err != nil {
-			return nil, err
+			return nil, "", false, err
 		}
 
 		var versions []Version
 		if err := json.Unmarshal(bodyBytes, &versions); err != nil {
-			zap.L().Error(
+			s.config.GetLogger().Error(
 				"Failed to unmarshal releases response",
 				zap.Error(err),
 				zap.Any("response", string(bodyBytes)),
 			)
-			return nil, err
+			return nil, "", false, err
 		}
 
 		// If the current page has no releases, break out of the loop
@@ -70,31 +279,97 @@ func (s *Solc) SyncReleases() ([]Version, error) {
 			break
 		}
 
+		// Refuse garbage pages so that a corrupt list never ends up in the cache.
+		for _, version := range versions {
+			if version.TagName == "" {
+				return nil, "", false, fmt.Errorf("invalid releases page %d: release without tag name", page)
+			}
+		}
+
+		if stop != nil {
+			for i, version := range versions {
+				if stop(version) {
+					return append(allVersions, versions[:i]...), etag, true, nil
+				}
+			}
+		}
+
 		allVersions = append(allVersions, versions...)
 		page++
 	}
 
+	return allVersions, etag, false, nil
+}
+
+// saveReleases saves the releases to releases.json and reloads the local cache with them.
+func (s *Solc) saveReleases(allVersions []Version, etag string) ([]Version, error) {
 	allVersionsBytes, err := json.Marshal(allVersions)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := os.WriteFile(s.GetLocalReleasesPath(), allVersionsBytes, 0600); err != nil {
+	// Write atomically so that a crash mid-write doesn't corrupt the releases cache.
+	if err := writeFileAtomic(s.GetLocalReleasesPath(), allVersionsBytes, 0600); err != nil {
 		return nil, err
 	}
 
 	s.localReleases = allVersions
 	s.lastSync = time.Now()
+	s.syncSource = SyncSourceNetwork
+	s.etag = etag
+
+	// Failing to persist the state only costs a redundant sync in the next process.
+	if err := s.saveSyncState(); err != nil {
+		s.config.GetLogger().Warn(
+			"Failed to persist releases sync state",
+			zap.String("path", s.GetSyncStatePath()),
+			zap.Error(err),
+		)
+	}
+
 	return allVersions, nil
 }
 
 // SyncBinaries downloads all the binaries for the specified versions in parallel.
+// With the solcjs fallback enabled, the soljson.js builds are downloaded for the versions without a native binary.
 func (s *Solc) SyncBinaries(versions []Version, limitVersion string) error {
+	return s.syncBinaries(s.ctx, versions, limitVersion)
+}
+
+// syncBinaries downloads the binaries for the specified versions like SyncBinaries, under the provided context.
+func (s *Solc) syncBinaries(ctx context.Context, versions []Version, limitVersion string) error {
+	if s.config.IsSolcJSFallback() {
+		return s.syncBinariesWithFallback(ctx, versions, limitVersion)
+	}
+
+	if err := s.checkPlatformSupported(); err != nil {
+		return err
+	}
+
+	return s.syncBinariesFor(ctx, versions, s.GetDistributionForAsset(), limitVersion)
+}
+
+// SyncBinariesFor downloads all the binaries for the specified versions and the explicitly provided distribution
+// in parallel. It allows populating a binary cache for an operating system other than the host one.
+func (s *Solc) SyncBinariesFor(versions []Version, distribution string, limitVersion string) error {
+	return s.syncBinariesFor(s.ctx, versions, distribution, limitVersion)
+}
+
+// syncBinariesFor downloads the binaries for the specified versions and distribution like SyncBinariesFor, under
+// the provided context.
+func (s *Solc) syncBinariesFor(ctx context.Context, versions []Version, distribution string, limitVersion string) error {
+	if !IsValidDistributionForAsset(distribution) {
+		return fmt.Errorf("invalid distribution provided: %s", distribution)
+	}
+
+	// Callers may provide the raw tag name (e.g. v0.8.20) which we need to compare against cleaned tags.
+	limitVersion = getCleanedVersionTag(limitVersion)
+
 	var wg sync.WaitGroup
 	errorsCh := make(chan error, len(versions))
-	progressCh := make(chan int, len(versions))
-	totalDownloads := 0
-	completedDownloads := 0
+
+	// Counters are updated by the download goroutines and read by the progress ticker concurrently.
+	var totalDownloads, completedDownloads atomic.Int64
 
 	for _, version := range versions {
 		versionTag := getCleanedVersionTag(version.TagName)
@@ -103,17 +378,12 @@ func (s *Solc) SyncBinaries(versions []Version, limitVersion string) error {
 		}
 
 		for _, asset := range version.Assets {
-			distribution := s.GetDistributionForAsset()
-
-			if strings.Contains(asset.Name, distribution) {
-				filename := fmt.Sprintf("%s/solc-%s", s.config.GetReleasesPath(), versionTag)
-				if distribution == "solc-windows" {
-					filename += ".exe"
-				}
+			if isAssetForDistribution(asset.Name, distribution) {
+				filename := filepath.Join(s.config.GetReleasesPath(), binaryFilenameFor(versionTag, distribution))
 
 				if _, err := os.Stat(filename); os.IsNotExist(err) {
-					totalDownloads++
-					zap.L().Info(
+					totalDownloads.Add(1)
+					s.syncLogger().Info(
 						"Downloading missing solc release",
 						zap.String("version", versionTag),
 						zap.String("asset_name", asset.Name),
@@ -128,8 +398,8 @@ func (s *Solc) SyncBinaries(versions []Version, limitVersion string) error {
 					go func(v Version, a Asset, fName string) {
 						defer wg.Done()
 						select {
-						case <-s.ctx.Done():
-							zap.L().Debug(
+						case <-ctx.Done():
+							s.syncLogger().Debug(
 								"Context cancelled. Stopping the download",
 								zap.String("version", versionTag),
 								zap.String("asset_name", asset.Name),
@@ -138,11 +408,18 @@ func (s *Solc) SyncBinaries(versions []Version, limitVersion string) error {
 							errorsCh <- fmt.Errorf("context cancelled")
 							return
 						default:
-							err := s.downloadFile(fName, a.BrowserDownloadURL)
+							hooks := s.config.GetHooks()
+							hooks.downloadStart(getCleanedVersionTag(v.TagName))
+
+							started := time.Now()
+							err := s.downloadFile(ctx, fName, v.TagName, a)
 							if err != nil {
+								hooks.downloadFail(getCleanedVersionTag(v.TagName), err)
 								errorsCh <- fmt.Errorf("error downloading binary for version %s: %v", getCleanedVersionTag(v.TagName), err)
+							} else {
+								hooks.downloadComplete(getCleanedVersionTag(v.TagName), time.Since(started))
 							}
-							progressCh <- 1
+							completedDownloads.Add(1)
 						}
 					}(version, asset, filename)
 				}
@@ -156,25 +433,18 @@ func (s *Solc) SyncBinaries(versions []Version, limitVersion string) error {
 	go func() {
 		for range ticker.C {
 			select {
-			case <-s.ctx.Done():
+			case <-ctx.Done():
 				return
 			default:
-				zap.L().Debug(fmt.Sprintf(
-					"Downloaded %d out of %d binaries\n", completedDownloads, totalDownloads,
+				s.syncLogger().Debug(fmt.Sprintf(
+					"Downloaded %d out of %d binaries\n", completedDownloads.Load(), totalDownloads.Load(),
 				))
 			}
 		}
 	}()
 
-	go func() {
-		for range progressCh {
-			completedDownloads++
-		}
-	}()
-
 	wg.Wait()
 	close(errorsCh)
-	close(progressCh)
 	ticker.Stop()
 
 	// One error is really enough. Could potentially troll the user with multiple errors but heck...
@@ -193,22 +463,43 @@ func (s *Solc) IsSynced() bool {
 }
 
 // Sync fetches the available Solidity versions from GitHub, saves them to releases.json, reloads the local cache,
-// and downloads all the binaries for the distribution for future use.
+// and downloads all the binaries for the distribution for future use. With a sync timeout configured, the whole
+// sync is cancelled once it's exceeded.
 func (s *Solc) Sync() error {
-	versions, err := s.SyncReleases()
-	if err != nil {
+	if err := s.checkSyncSupported(); err != nil {
 		return err
 	}
 
-	zap.L().Debug("Syncing solc binaries...", zap.Int("versions_count", len(versions)))
+	ctx := s.ctx
+	timeout := s.config.GetSyncTimeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
 
-	if err := s.SyncBinaries(versions, ""); err != nil {
+	if err := s.sync(ctx); err != nil {
+		if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("sync exceeded the timeout of %s: %w", timeout, context.DeadlineExceeded)
+		}
 		return err
 	}
 
 	return nil
 }
 
+// sync fetches the releases and downloads all the binaries for the distribution under the provided context.
+func (s *Solc) sync(ctx context.Context) error {
+	versions, err := s.syncReleases(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.syncLogger().Debug("Syncing solc binaries...", zap.Int("versions_count", len(versions)))
+
+	return s.syncBinaries(ctx, versions, "")
+}
+
 // SyncOne fetches a specific Solidity version from GitHub, saves it to releases.json, reloads the local cache,
 // and downloads the binary for the distribution for future use.
 func (s *Solc) SyncOne(version *Version) error {
@@ -216,12 +507,16 @@ func (s *Solc) SyncOne(version *Version) error {
 		return fmt.Errorf("version must be provided to synchronize one version")
 	}
 
+	if err := s.checkSyncSupported(); err != nil {
+		return err
+	}
+
 	versions, err := s.SyncReleases()
 	if err != nil {
 		return err
 	}
 
-	zap.L().Debug(
+	s.syncLogger().Debug(
 		"Attempt to synchronize solc release", zap.Int("versions_count", len(versions)),
 		zap.String("version", getCleanedVersionTag(version.TagName)),
 	)
@@ -233,28 +528,361 @@ func (s *Solc) SyncOne(version *Version) error {
 	return nil
 }
 
-// downloadFile downloads a file from the provided URL and saves it to the specified path.
-func (s *Solc) downloadFile(file string, url string) error {
-	// Just a bit of the time because we could receive 503 from GitHub so we don't want to spam them
-	randomDelayBetween500And1500()
+// SyncVersions fetches the available Solidity versions from GitHub, saves them to releases.json, reloads the local
+// cache, and downloads the binaries of only the listed versions for the distribution, concurrently.
+func (s *Solc) SyncVersions(versions []string) error {
+	if len(versions) == 0 {
+		return fmt.Errorf("versions must be provided to synchronize")
+	}
+
+	if err := s.checkSyncSupported(); err != nil {
+		return err
+	}
+
+	releases, err := s.SyncReleases()
+	if err != nil {
+		return err
+	}
+
+	selected := make([]Version, 0, len(versions))
+	for _, version := range versions {
+		resolved, err := s.ResolveVersion(version)
+		if err != nil {
+			return err
+		}
+
+		release, err := s.GetRelease(resolved)
+		if err != nil {
+			return fmt.Errorf("failed to synchronize version %s: %w", version, err)
+		}
+
+		selected = append(selected, *release)
+	}
+
+	s.syncLogger().Debug(
+		"Attempt to synchronize solc releases", zap.Int("versions_count", len(releases)),
+		zap.Strings("versions", versions),
+	)
 
-	// Construct the curl command
-	curlCmd := exec.Command("curl", "-s", "-L", url, "-o", file)
+	return s.SyncBinaries(selected, "")
+}
+
+// SyncNew fetches the Solidity versions released since the last sync, merging them into releases.json, and
+// downloads the binaries of those new versions which aren't installed yet. It returns the downloaded versions.
+// Unlike Sync it neither re-fetches the whole release history nor looks at the binaries of older versions, which
+// makes it suitable for periodic runs picking up the latest releases. Without any cached releases every version
+// is new, so it amounts to a full sync.
+func (s *Solc) SyncNew() ([]string, error) {
+	if err := s.checkSyncSupported(); err != nil {
+		return nil, err
+	}
+
+	cached := s.GetCachedReleases()
+	if cached == nil {
+		cached, _ = s.GetLocalReleases()
+	}
+
+	known := make(map[string]bool, len(cached))
+	for _, version := range cached {
+		known[getCleanedVersionTag(version.TagName)] = true
+	}
+
+	releases, err := s.SyncReleasesSince("")
+	if err != nil {
+		return nil, err
+	}
+
+	var fresh []Version
+	for _, version := range releases {
+		versionTag := getCleanedVersionTag(version.TagName)
+		if !known[versionTag] && !s.hasBinary(versionTag) {
+			fresh = append(fresh, version)
+		}
+	}
+
+	if len(fresh) == 0 {
+		return nil, nil
+	}
+
+	if err := s.SyncBinaries(fresh, ""); err != nil {
+		return nil, err
+	}
+
+	// Releases without a binary for the current platform are skipped by the sync.
+	var downloaded []string
+	for _, version := range fresh {
+		if versionTag := getCleanedVersionTag(version.TagName); s.hasBinary(versionTag) {
+			downloaded = append(downloaded, versionTag)
+		}
+	}
+
+	return downloaded, nil
+}
+
+// hasBinary reports whether the native binary, or the soljson.js build, of the specified version is installed.
+func (s *Solc) hasBinary(version string) bool {
+	if s.IsInstalled(version) {
+		return true
+	}
+
+	_, err := os.Stat(s.SolcJSPath(version))
+	return err == nil
+}
+
+// DownloadBinaryTo downloads the binary of the specified version for the current distribution to the provided
+// destination path, outside of the managed releases path. The download is verified against the size and checksum
+// reported by GitHub and made executable. An existing file at the destination path is replaced.
+func (s *Solc) DownloadBinaryTo(version string, destPath string) error {
+	if err := s.checkPlatformSupported(); err != nil {
+		return err
+	}
+
+	version, err := s.ResolveVersion(version)
+	if err != nil {
+		return err
+	}
+
+	release, err := s.GetRelease(version)
+	if err != nil {
+		return err
+	}
+
+	distribution := s.GetDistributionForAsset()
+	for _, asset := range release.Assets {
+		if !isAssetForDistribution(asset.Name, distribution) {
+			continue
+		}
+
+		hooks := s.config.GetHooks()
+		hooks.downloadStart(version)
+
+		started := time.Now()
+		if err := s.downloadFileTo(destPath, version, asset); err != nil {
+			hooks.downloadFail(version, err)
+			return fmt.Errorf("error downloading binary for version %s: %w", version, err)
+		}
+		hooks.downloadComplete(version, time.Since(started))
+
+		return nil
+	}
+
+	return fmt.Errorf("no %s binary distributed for version %s", distribution, version)
+}
+
+// downloadFileTo downloads the asset next to the destination path and moves it into place once verified, so that
+// a failed download never leaves a partial binary behind.
+func (s *Solc) downloadFileTo(destPath string, version string, asset Asset) error {
+	tmp, err := os.CreateTemp(filepath.Dir(destPath), "."+filepath.Base(destPath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := s.downloadFile(s.ctx, tmpPath, version, asset); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
+
+// downloadFile downloads the asset of the specified version and saves it to the specified path, verifying it when
+// possible. Concurrent downloads to the same path are coalesced into one, the callers joining an in-flight download
+// wait for it and share its outcome rather than racing on the file.
+func (s *Solc) downloadFile(ctx context.Context, file string, version string, asset Asset) error {
+	shared, err := s.downloads.Do(file, func() error {
+		return s.fetchFile(ctx, file, version, asset)
+	})
+
+	if shared {
+		s.config.GetLogger().Debug(
+			"Joined in-flight download of the asset",
+			zap.String("version", getCleanedVersionTag(version)),
+			zap.String("asset_name", asset.Name),
+		)
+	}
+
+	return err
+}
+
+// fetchFile downloads the asset of the specified version, saves it to the specified path, and makes it executable.
+// With download mirrors configured, they're tried in order until one of them succeeds.
+func (s *Solc) fetchFile(ctx context.Context, file string, version string, asset Asset) error {
+	urls := []string{asset.BrowserDownloadURL}
+	if mirrors := s.config.GetDownloadMirrors(); len(mirrors) > 0 {
+		urls = make([]string, 0, len(mirrors))
+		for _, mirror := range mirrors {
+			urls = append(urls, expandMirrorURL(mirror, version, asset.Name))
+		}
+	}
+
+	var errs []error
+	for _, url := range urls {
+		err := s.downloadFileFrom(ctx, file, url, asset)
+		if err == nil {
+			errs = nil
+			break
+		}
+
+		// The context being cancelled isn't a mirror failure, so don't try the remaining ones.
+		if ctx.Err() != nil {
+			return err
+		}
+
+		if len(urls) > 1 {
+			s.config.GetLogger().Warn(
+				"Failed to download asset from mirror",
+				zap.String("version", getCleanedVersionTag(version)),
+				zap.String("url", url),
+				zap.Error(err),
+			)
+			err = fmt.Errorf("%s: %w", url, err)
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// Some releases ship the binary packaged in an archive (e.g. Windows zip with DLLs) so we need to extract it.
+	if err := extractArchiveIfNeeded(file); err != nil {
+		return fmt.Errorf("failed to extract downloaded asset: %v", err)
+	}
+
+	mode := s.config.GetBinaryFileMode()
+	if s.config.IsReadOnlyBinaries() {
+		mode &^= 0222
+	}
+
+	// #nosec G302
+	if err := os.Chmod(file, mode); err != nil {
+		return fmt.Errorf("failed to set file as executable: %v", err)
+	}
+
+	return nil
+}
+
+// downloadFileFrom downloads the asset from the URL and saves it to the specified path, verifying it when possible.
+func (s *Solc) downloadFileFrom(ctx context.Context, file string, url string, asset Asset) error {
+	if err := s.waitRateLimit(ctx); err != nil {
+		return err
+	}
+
+	// Just a bit of the time because we could receive 503 from GitHub so we don't want to spam them. Not needed
+	// when the rate limiting is disabled as the assets are then served by a private mirror.
+	if s.limiter != nil {
+		randomDelayBetween500And1500()
+	}
+
+	// Construct the curl command, reporting the status code and the content type of the final response on stdout.
+	curlCmd := exec.CommandContext(ctx, "curl", "-s", "-L", "-w", "%{http_code} %{content_type}", url, "-o", file)
 	curlCmd.Stderr = os.Stderr
 
 	// Execute curl
-	if err := curlCmd.Run(); err != nil {
+	response, err := curlCmd.Output()
+	if err != nil {
+		_ = os.Remove(file)
 		return fmt.Errorf("curl command failed: %v", err)
 	}
 
-	// #nosec G302
-	if err := os.Chmod(file, 0755); err != nil {
-		return fmt.Errorf("failed to set file as executable: %v", err)
+	if err := checkDownloadedPayload(file, string(response)); err != nil {
+		_ = os.Remove(file)
+		return err
+	}
+
+	if err := verifyAsset(file, asset); err != nil {
+		_ = os.Remove(file)
+		return err
+	}
+
+	return nil
+}
+
+// expandMirrorURL expands the {version} and {filename} placeholders of a download mirror URL template.
+func expandMirrorURL(template string, version string, filename string) string {
+	return strings.NewReplacer(
+		"{version}", getCleanedVersionTag(version),
+		"{filename}", filename,
+	).Replace(template)
+}
+
+// checkDownloadedPayload rejects downloads which obviously aren't a binary, such as error pages GitHub occasionally
+// serves when redirects misbehave. The response is the "<status code> <content type>" reported by curl.
+func checkDownloadedPayload(file string, response string) error {
+	statusCode, contentType, _ := strings.Cut(strings.TrimSpace(response), " ")
+	if code, err := strconv.Atoi(statusCode); err == nil && code >= http.StatusBadRequest {
+		return fmt.Errorf("downloaded asset rejected: unexpected status code %d", code)
+	}
+
+	if mediaType, _, _ := strings.Cut(contentType, ";"); strings.EqualFold(strings.TrimSpace(mediaType), "text/html") {
+		return fmt.Errorf("downloaded asset rejected: unexpected content type %s", contentType)
+	}
+
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+
+	prefix := strings.ToLower(strings.TrimSpace(string(head[:n])))
+	if strings.HasPrefix(prefix, "<!doctype html") || strings.HasPrefix(prefix, "<html") {
+		return fmt.Errorf("downloaded asset rejected: payload is an html page")
 	}
 
 	return nil
 }
 
+// verifyAsset checks the downloaded asset against the size and the SHA-256 digest reported by GitHub. Checks for
+// which GitHub didn't report the expected value are skipped.
+func verifyAsset(file string, asset Asset) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	if asset.Size > 0 && info.Size() != int64(asset.Size) {
+		return fmt.Errorf("downloaded asset size mismatch: expected %d bytes, got %d", asset.Size, info.Size())
+	}
+
+	if algorithm, expected, ok := strings.Cut(asset.Digest, ":"); ok && algorithm == "sha256" {
+		checksum, err := fileChecksum(file)
+		if err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(checksum, expected) {
+			return fmt.Errorf("downloaded asset checksum mismatch: expected sha256 %s, got %s", expected, checksum)
+		}
+	}
+
+	return nil
+}
+
+// syncLogger returns the logger used for informational sync output. In quiet mode informational logs are
+// suppressed while warnings and errors are still emitted, regardless of the configured logger level.
+func (s *Solc) syncLogger() *zap.Logger {
+	if s.config.IsQuiet() {
+		return s.config.GetLogger().WithOptions(zap.IncreaseLevel(zapcore.WarnLevel))
+	}
+
+	return s.config.GetLogger()
+}
+
 // randomDelayBetween500And1500 sleeps for a random amount of time between 500 and 1500 milliseconds.
 func randomDelayBetween500And1500() {
 	n, err := rand.Int(rand.Reader, big.NewInt(1001))
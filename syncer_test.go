@@ -2,13 +2,24 @@ package solc
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // TestSyncer tests the Syncer but as well builds the releases in the releases path.
@@ -28,12 +39,7 @@ func TestSyncer(t *testing.T) {
 		{
 			name:         "Download Binaries Successfully",
 			expectedGOOS: runtime.GOOS,
-			config: func() *Config {
-				config, err := NewDefaultConfig()
-				assert.NoError(t, err)
-				assert.NotNil(t, config)
-				return config
-			}(),
+			config:       newTestConfig(t),
 		},
 	}
 
@@ -75,12 +81,7 @@ func TestSyncOnce(t *testing.T) {
 		{
 			name:         "Download Binaries Successfully",
 			expectedGOOS: runtime.GOOS,
-			config: func() *Config {
-				config, err := NewDefaultConfig()
-				assert.NoError(t, err)
-				assert.NotNil(t, config)
-				return config
-			}(),
+			config:       newTestConfig(t),
 		},
 	}
 
@@ -113,3 +114,601 @@ func TestSyncOnce(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncReleasesAuthorizationHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		expected string
+	}{
+		{
+			name:     "Without Token",
+			token:    "",
+			expected: "",
+		},
+		{
+			name:     "With Token",
+			token:    "secret",
+			expected: "token secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authorization []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = append(authorization, r.Header.Get("Authorization"))
+				_, _ = w.Write([]byte("[]"))
+			}))
+			defer server.Close()
+
+			s := newTestSolc(t)
+			s.config.releasesUrl = server.URL
+			s.config.personalAccessToken = tt.token
+
+			_, err := s.SyncReleases()
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tt.expected}, authorization)
+		})
+	}
+}
+
+func TestSyncLoggerQuiet(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	s := newTestSolc(t)
+	s.config.SetLogger(zap.New(core))
+
+	s.syncLogger().Info("informational")
+	assert.Equal(t, 1, logs.Len())
+
+	s.config.SetQuiet(true)
+	assert.True(t, s.config.IsQuiet())
+
+	s.syncLogger().Info("informational")
+	s.syncLogger().Debug("debug")
+	assert.Equal(t, 1, logs.Len())
+
+	s.syncLogger().Error("error")
+	assert.Equal(t, 2, logs.Len())
+}
+
+func TestSyncReleasesContextCancelled(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`[{"tag_name": "v0.8.20"}]`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := newTestSolc(t)
+	s.ctx = ctx
+	s.config.releasesUrl = server.URL
+
+	versions, err := s.SyncReleases()
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, versions)
+	assert.Equal(t, 0, requests)
+}
+
+func TestSyncReleasesPartialFailure(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages map[string]string
+	}{
+		{
+			name: "Garbage Page",
+			pages: map[string]string{
+				"1": `[{"tag_name": "v0.8.20"}]`,
+				"2": `[{"name": "not a release"}]`,
+			},
+		},
+		{
+			name: "Invalid JSON Page",
+			pages: map[string]string{
+				"1": `[{"tag_name": "v0.8.20"}]`,
+				"2": `{"message": "API rate limit exceeded"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page, ok := tt.pages[r.URL.Query().Get("page")]
+				if !ok {
+					page = "[]"
+				}
+				_, _ = w.Write([]byte(page))
+			}))
+			defer server.Close()
+
+			s := newTestSolc(t, Version{TagName: "v0.8.19"})
+			s.config.releasesUrl = server.URL
+			s.config.SetLogger(zap.NewNop())
+
+			before, err := os.ReadFile(s.GetLocalReleasesPath())
+			assert.NoError(t, err)
+
+			_, err = s.SyncReleases()
+			assert.Error(t, err)
+			assert.True(t, s.LastSyncTime().IsZero())
+
+			after, err := os.ReadFile(s.GetLocalReleasesPath())
+			assert.NoError(t, err)
+			assert.Equal(t, before, after)
+		})
+	}
+}
+
+func TestSyncReleasesWritesAtomically(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			_, _ = w.Write([]byte(`[{"tag_name": "v0.8.21"}, {"tag_name": "v0.8.20"}]`))
+			return
+		}
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t)
+	s.config.releasesUrl = server.URL
+
+	versions, err := s.SyncReleases()
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.False(t, s.LastSyncTime().IsZero())
+
+	entries, err := os.ReadDir(s.GetConfig().GetReleasesPath())
+	assert.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"releases.json", "releases.sync.json"}, names, "no temporary file must be left behind")
+}
+
+func TestSyncReleasesPersistsSyncState(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("ETag", `"abc"`)
+			_, _ = w.Write([]byte(`[{"tag_name": "v0.8.20"}]`))
+			return
+		}
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t)
+	s.config.releasesUrl = server.URL
+
+	_, err := s.SyncReleases()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	// A fresh instance, as in a new process, restores the last sync and doesn't hit the network again.
+	restarted, err := New(context.TODO(), s.GetConfig())
+	assert.NoError(t, err)
+	assert.True(t, s.LastSyncTime().Equal(restarted.LastSyncTime()))
+	assert.Equal(t, `"abc"`, restarted.GetSyncStatus().ETag)
+
+	versions, err := restarted.SyncReleases()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Len(t, versions, 1)
+
+	// A corrupt state is ignored and results in a regular sync.
+	assert.NoError(t, os.WriteFile(s.GetSyncStatePath(), []byte("{"), 0600))
+	restarted, err = New(context.TODO(), s.GetConfig())
+	assert.NoError(t, err)
+	assert.True(t, restarted.LastSyncTime().IsZero())
+
+	_, err = restarted.SyncReleases()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, requests)
+}
+
+func TestSyncVersions(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/download":
+			_, _ = w.Write([]byte("#!/bin/sh\n"))
+		case r.URL.Query().Get("page") == "1":
+			var releases []Version
+			for _, tag := range []string{"v0.8.22", "v0.8.21", "v0.8.20"} {
+				releases = append(releases, Version{
+					TagName: tag,
+					Assets:  []Asset{{Name: "solc-static-linux", BrowserDownloadURL: server.URL + "/download"}},
+				})
+			}
+			_ = json.NewEncoder(w).Encode(releases)
+		default:
+			_, _ = w.Write([]byte("[]"))
+		}
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t)
+	s.gOOSFunc = func() string { return "linux" }
+	s.config.releasesUrl = server.URL
+	s.config.SetLogger(zap.NewNop())
+
+	assert.Error(t, s.SyncVersions(nil))
+	assert.NoError(t, s.SyncVersions([]string{"0.8.20", "latest"}))
+
+	assert.True(t, s.IsInstalled("0.8.20"))
+	assert.False(t, s.IsInstalled("0.8.21"))
+	assert.True(t, s.IsInstalled("0.8.22"))
+
+	assert.ErrorContains(t, s.SyncVersions([]string{"0.8.19"}), "failed to synchronize version 0.8.19")
+}
+
+func TestDownloadBinaryTo(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho solc\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(binary)
+	}))
+	defer server.Close()
+
+	checksum := sha256.Sum256(binary)
+	digest := "sha256:" + hex.EncodeToString(checksum[:])
+
+	s := newTestSolc(t,
+		Version{TagName: "v0.8.20", Assets: []Asset{
+			{Name: "solc-static-linux", BrowserDownloadURL: server.URL, Size: len(binary), Digest: digest},
+		}},
+		Version{TagName: "v0.8.19", Assets: []Asset{
+			{Name: "solc-static-linux", BrowserDownloadURL: server.URL, Digest: "sha256:" + strings.Repeat("0", 64)},
+		}},
+		Version{TagName: "v0.8.18", Assets: []Asset{
+			{Name: "solc-static-linux", BrowserDownloadURL: server.URL, Size: 1},
+		}},
+		Version{TagName: "v0.8.17", Assets: []Asset{{Name: "solc-macos", BrowserDownloadURL: server.URL}}},
+	)
+	s.gOOSFunc = func() string { return "linux" }
+
+	destDir := t.TempDir()
+	destPath := filepath.Join(destDir, "solc")
+
+	assert.NoError(t, s.DownloadBinaryTo("v0.8.20", destPath))
+	data, err := os.ReadFile(destPath)
+	assert.NoError(t, err)
+	assert.Equal(t, binary, data)
+	assert.False(t, s.IsInstalled("0.8.20"), "the managed cache must be left untouched")
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(destPath)
+		assert.NoError(t, err)
+		assert.NotZero(t, info.Mode()&0100, "binary must be executable")
+	}
+
+	assert.ErrorContains(t, s.DownloadBinaryTo("0.8.19", filepath.Join(destDir, "bad-checksum")), "checksum mismatch")
+	assert.ErrorContains(t, s.DownloadBinaryTo("0.8.18", filepath.Join(destDir, "bad-size")), "size mismatch")
+	assert.ErrorContains(t, s.DownloadBinaryTo("0.8.17", filepath.Join(destDir, "missing")), "no solc-static-linux binary")
+	assert.Error(t, s.DownloadBinaryTo("0.8.16", filepath.Join(destDir, "unknown")))
+
+	entries, err := os.ReadDir(destDir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "failed downloads must not leave files behind")
+}
+
+func TestCheckDownloadedPayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		payload  string
+		wantErr  string
+	}{
+		{name: "binary", response: "200 application/octet-stream", payload: "\x7fELF\x02\x01\x01"},
+		{name: "script", response: "200 text/plain; charset=utf-8", payload: "#!/bin/sh\n"},
+		{name: "unknown response", response: "", payload: "\x7fELF"},
+		{name: "not found", response: "404 application/octet-stream", payload: "Not Found", wantErr: "status code 404"},
+		{name: "html content type", response: "200 text/html; charset=utf-8", payload: "\x7fELF", wantErr: "content type"},
+		{name: "html payload", response: "200 application/octet-stream", payload: "\n  <!DOCTYPE html><html></html>", wantErr: "html page"},
+		{name: "html payload without doctype", response: "200 ", payload: "<HTML><body></body></HTML>", wantErr: "html page"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "solc")
+			assert.NoError(t, os.WriteFile(file, []byte(tt.payload), 0600))
+
+			err := checkDownloadedPayload(file, tt.response)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDownloadBinaryToRejectsHTML(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<!DOCTYPE html><html><body>Not Found</body></html>"))
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t, Version{TagName: "v0.8.20", Assets: []Asset{{Name: "solc-static-linux", BrowserDownloadURL: server.URL}}})
+	s.gOOSFunc = func() string { return "linux" }
+
+	destPath := filepath.Join(t.TempDir(), "solc")
+	assert.ErrorContains(t, s.DownloadBinaryTo("0.8.20", destPath), "downloaded asset rejected")
+	assert.NoFileExists(t, destPath)
+}
+
+func TestSyncReleasesSince(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		switch page {
+		case "1":
+			_, _ = w.Write([]byte(`[{"tag_name": "v0.8.22"}, {"tag_name": "v0.8.21"}]`))
+		case "2":
+			_, _ = w.Write([]byte(`[{"tag_name": "v0.8.20"}, {"tag_name": "v0.8.19"}]`))
+		default:
+			_, _ = w.Write([]byte("[]"))
+		}
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t, Version{TagName: "v0.8.21"}, Version{TagName: "v0.8.20"}, Version{TagName: "v0.8.19"})
+	s.config.releasesUrl = server.URL
+
+	versions, err := s.SyncReleasesSince("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, pages, "only the first page must be fetched")
+
+	var tags []string
+	for _, version := range versions {
+		tags = append(tags, version.TagName)
+	}
+	assert.Equal(t, []string{"v0.8.22", "v0.8.21", "v0.8.20", "v0.8.19"}, tags)
+
+	s.ClearCache()
+	local, err := s.GetLocalReleases()
+	assert.NoError(t, err)
+	assert.Equal(t, versions, local)
+	assert.False(t, s.LastSyncTime().IsZero())
+
+	pages = nil
+	_, err = s.SyncReleasesSince("v0.8.19")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages)
+
+	_, err = s.SyncReleasesSince("0.8.10")
+	assert.ErrorContains(t, err, "not cached")
+
+	// Without any cached releases, all the releases are fetched.
+	empty := newTestSolc(t)
+	empty.config.releasesUrl = server.URL
+	assert.NoError(t, os.Remove(empty.GetLocalReleasesPath()))
+
+	pages = nil
+	versions, err = empty.SyncReleasesSince("")
+	assert.NoError(t, err)
+	assert.Len(t, versions, 4)
+	assert.Equal(t, []string{"1", "2", "3"}, pages)
+
+	empty.config.SetOffline(true)
+	_, err = empty.SyncReleasesSince("")
+	assert.ErrorIs(t, err, ErrOffline)
+}
+
+func TestSyncReleasesPerPage(t *testing.T) {
+	var perPage []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		perPage = append(perPage, r.URL.Query().Get("per_page"))
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t)
+	s.config.releasesUrl = server.URL
+
+	_, err := s.SyncReleases()
+	assert.NoError(t, err)
+
+	s.config.SetReleasesPerPage(30)
+	_, err = s.SyncReleasesSince("")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"100", "30"}, perPage)
+}
+
+func TestDownloadMirrors(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho solc\n")
+
+	var mu sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+
+		if !strings.HasPrefix(r.URL.Path, "/mirror/") {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(binary)
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t, Version{TagName: "v0.8.20", Assets: []Asset{
+		{Name: "solc-static-linux", BrowserDownloadURL: server.URL + "/github", Size: len(binary)},
+	}})
+	s.gOOSFunc = func() string { return "linux" }
+	s.limiter = nil
+
+	assert.Error(t, s.config.SetDownloadMirrors([]string{"/relative/{filename}"}))
+	assert.NoError(t, s.config.SetDownloadMirrors([]string{
+		server.URL + "/blocked/v{version}/{filename}",
+		server.URL + "/mirror/v{version}/{filename}",
+	}))
+
+	destPath := filepath.Join(t.TempDir(), "solc")
+	assert.NoError(t, s.DownloadBinaryTo("0.8.20", destPath))
+
+	mu.Lock()
+	paths := append([]string(nil), requested...)
+	mu.Unlock()
+	assert.Equal(t, []string{"/blocked/v0.8.20/solc-static-linux", "/mirror/v0.8.20/solc-static-linux"}, paths)
+
+	data, err := os.ReadFile(destPath)
+	assert.NoError(t, err)
+	assert.Equal(t, binary, data)
+
+	// Every mirror failing is reported along with each of the failures.
+	assert.NoError(t, s.config.SetDownloadMirrors([]string{server.URL + "/blocked/{filename}", server.URL + "/gone/{filename}"}))
+	err = s.DownloadBinaryTo("0.8.20", filepath.Join(t.TempDir(), "solc"))
+	assert.ErrorContains(t, err, "/blocked/solc-static-linux: downloaded asset rejected: unexpected status code 404")
+	assert.ErrorContains(t, err, "/gone/solc-static-linux: downloaded asset rejected: unexpected status code 404")
+}
+
+func TestSyncNew(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho solc\n")
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/binary" {
+			_, _ = w.Write(binary)
+			return
+		}
+
+		if r.URL.Query().Get("page") != "1" {
+			_, _ = w.Write([]byte("[]"))
+			return
+		}
+
+		releases := []Version{
+			{TagName: "v0.8.22", Assets: []Asset{{Name: "solc-static-linux", BrowserDownloadURL: server.URL + "/binary"}}},
+			{TagName: "v0.8.21", Assets: []Asset{{Name: "solc-macos", BrowserDownloadURL: server.URL + "/binary"}}},
+			{TagName: "v0.8.20", Assets: []Asset{{Name: "solc-static-linux", BrowserDownloadURL: server.URL + "/binary"}}},
+		}
+		data, err := json.Marshal(releases)
+		assert.NoError(t, err)
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t, Version{TagName: "v0.8.20", Assets: []Asset{{Name: "solc-static-linux", BrowserDownloadURL: server.URL + "/binary"}}})
+	s.config.releasesUrl = server.URL
+	s.gOOSFunc = func() string { return "linux" }
+	s.limiter = nil
+
+	downloaded, err := s.SyncNew()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0.8.22"}, downloaded, "releases without a binary for the platform are skipped")
+	assert.True(t, s.IsInstalled("0.8.22"))
+	assert.False(t, s.IsInstalled("0.8.20"), "binaries of releases known before aren't downloaded")
+
+	downloaded, err = s.SyncNew()
+	assert.NoError(t, err)
+	assert.Empty(t, downloaded)
+}
+
+func TestDownloadCoalescing(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho solc\n")
+
+	var requests atomic.Int64
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+		_, _ = w.Write(binary)
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t)
+	s.gOOSFunc = func() string { return "linux" }
+	s.limiter = nil
+
+	asset := Asset{Name: "solc-static-linux", BrowserDownloadURL: server.URL, Size: len(binary)}
+	file := s.BinaryPath("0.8.20")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, s.downloadFile(context.Background(), file, "v0.8.20", asset))
+		}()
+	}
+
+	// Give the goroutines the time to join the in-flight download before letting it complete.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), requests.Load())
+	data, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, binary, data)
+
+	// Completed downloads aren't cached, later calls download again.
+	assert.NoError(t, s.downloadFile(context.Background(), file, "v0.8.20", asset))
+	assert.Equal(t, int64(2), requests.Load())
+}
+
+func TestSyncTimeout(t *testing.T) {
+	var s *Solc
+	var unaffected atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Operations running meanwhile on the instance context aren't bound by the sync timeout.
+		unaffected.Store(s.GetContext().Err() == nil && r.Context().Err() == nil)
+
+		// Hang until the client gives up, like a stuck GitHub request.
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	s = newTestSolc(t)
+	s.gOOSFunc = func() string { return "linux" }
+	s.config.releasesUrl = server.URL
+	s.config.SetHttpClientTimeout(time.Minute)
+	s.config.SetSyncTimeout(100 * time.Millisecond)
+
+	started := time.Now()
+	err := s.Sync()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "sync exceeded the timeout of 100ms")
+	assert.Less(t, time.Since(started), 10*time.Second)
+	assert.True(t, unaffected.Load())
+	assert.NoError(t, s.GetContext().Err())
+}
+
+func TestReadOnlyBinaries(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho solc\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(binary)
+	}))
+	defer server.Close()
+
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	s.gOOSFunc = func() string { return "linux" }
+	s.limiter = nil
+
+	assert.False(t, s.config.IsReadOnlyBinaries())
+	s.config.SetReadOnlyBinaries(true)
+	assert.True(t, s.config.IsReadOnlyBinaries())
+
+	asset := Asset{Name: "solc-static-linux", BrowserDownloadURL: server.URL, Size: len(binary)}
+	file := s.BinaryPath("0.8.20")
+	assert.NoError(t, s.downloadFile(context.Background(), file, "v0.8.20", asset))
+
+	info, err := os.Stat(file)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0555), info.Mode().Perm())
+
+	// Read-only binaries can still be removed.
+	assert.NoError(t, s.RemoveBinary("0.8.20"))
+	assert.False(t, s.IsInstalled("0.8.20"))
+}
@@ -1,10 +1,14 @@
 package solc
 
-// VersionInfo represents a simplified structure containing only the version tag name and an indication if it's the latest/prerelease version.
+import "time"
+
+// VersionInfo represents a simplified structure containing only the version tag name, the publish date and an indication if it's the latest/prerelease/installed version.
 type VersionInfo struct {
-	TagName      string `json:"tag_name"`
-	IsLatest     bool   `json:"is_latest"`
-	IsPrerelease bool   `json:"is_prerelease"`
+	TagName      string    `json:"tag_name"`
+	IsLatest     bool      `json:"is_latest"`
+	IsPrerelease bool      `json:"is_prerelease"`
+	PublishedAt  time.Time `json:"published_at"`
+	IsInstalled  bool      `json:"is_installed"`
 }
 
 // Version represents the structure of a Solidity version.
@@ -51,14 +55,26 @@ type Version struct {
 }
 
 // GetVersionInfo returns a VersionInfo struct containing the version's tag name and an indication if it's the latest version.
+// The publish date is left as zero time if it cannot be parsed.
 func (v *Version) GetVersionInfo(latestVersionTag string) VersionInfo {
 	return VersionInfo{
 		TagName:      v.TagName,
 		IsLatest:     v.TagName == latestVersionTag,
 		IsPrerelease: v.Prerelease,
+		PublishedAt:  v.GetPublishedAt(),
 	}
 }
 
+// GetPublishedAt returns the parsed timestamp when this release was published or zero time if it cannot be parsed.
+func (v *Version) GetPublishedAt() time.Time {
+	publishedAt, err := time.Parse(time.RFC3339, v.PublishedAt)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return publishedAt
+}
+
 // Asset represents a downloadable asset associated with a release.
 type Asset struct {
 	// URL is the API URL for this asset.
@@ -87,6 +103,8 @@ type Asset struct {
 	UpdatedAt string `json:"updated_at"`
 	// BrowserDownloadURL is the URL to download the asset.
 	BrowserDownloadURL string `json:"browser_download_url"`
+	// Digest is the checksum of the asset prefixed by its algorithm (e.g. "sha256:..."), when reported by GitHub.
+	Digest string `json:"digest"`
 }
 
 // Author represents the user who published a release or uploaded an asset.
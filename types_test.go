@@ -0,0 +1,44 @@
+package solc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVersionInfo(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   Version
+		latestTag string
+		expected  VersionInfo
+	}{
+		{
+			name:      "Latest Version",
+			version:   Version{TagName: "v0.8.21", PublishedAt: "2023-07-19T13:18:49Z"},
+			latestTag: "v0.8.21",
+			expected: VersionInfo{
+				TagName:     "v0.8.21",
+				IsLatest:    true,
+				PublishedAt: time.Date(2023, time.July, 19, 13, 18, 49, 0, time.UTC),
+			},
+		},
+		{
+			name:      "Invalid Published Date",
+			version:   Version{TagName: "v0.8.20", Prerelease: true, PublishedAt: "not a date"},
+			latestTag: "v0.8.21",
+			expected: VersionInfo{
+				TagName:      "v0.8.20",
+				IsPrerelease: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := tt.version.GetVersionInfo(tt.latestTag)
+			assert.Equal(t, tt.expected, info)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package solc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CompileSources compiles the provided sources, keyed by their path relative to the project root, by writing
+// them into a working directory and compiling it like CompileDir. The working directory is kept per project
+// identifier and reused across calls, only changed sources are rewritten and removed ones are deleted, which keeps
+// repeated compiles of large projects (e.g. in watch mode) cheap. The provided sources are passed to solc as is
+// rather than read back from the working directory. Compiles of the same project are serialized, so that solc
+// never reads sources rewritten by a concurrent compile. Working directories are removed by Cleanup.
+func (s *Solc) CompileSources(ctx context.Context, project string, sources map[string]string, config *CompilerConfig) (*CompilerResults, error) {
+	if project == "" {
+		return nil, fmt.Errorf("project identifier must be provided to compile sources")
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("sources must be provided to compile")
+	}
+
+	if config == nil {
+		return nil, fmt.Errorf("config must be provided to compile sources")
+	}
+
+	dir, err := s.lockWorkDir(project)
+	if err != nil {
+		return nil, err
+	}
+	defer dir.mu.Unlock()
+
+	known, err := syncWorkDir(dir.path, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.compileDir(ctx, dir.path, known, config)
+}
+
+// Cleanup removes all the working directories created by CompileSources. It waits for the compiles running in
+// them to complete.
+func (s *Solc) Cleanup() error {
+	s.workDirsMu.Lock()
+	defer s.workDirsMu.Unlock()
+
+	var errs []error
+	for project, dir := range s.workDirs {
+		dir.mu.Lock()
+		err := os.RemoveAll(dir.path)
+		if err == nil {
+			dir.removed = true
+		}
+		dir.mu.Unlock()
+
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delete(s.workDirs, project)
+	}
+
+	return errors.Join(errs...)
+}
+
+// projectDir is the working directory of a project compiled with CompileSources.
+type projectDir struct {
+	mu      sync.Mutex // Held while the directory is synced and compiled.
+	path    string
+	removed bool // Whether Cleanup removed the directory.
+}
+
+// workDir returns the working directory of the project, creating it on first use.
+func (s *Solc) workDir(project string) (*projectDir, error) {
+	s.workDirsMu.Lock()
+	defer s.workDirsMu.Unlock()
+
+	if dir, ok := s.workDirs[project]; ok {
+		return dir, nil
+	}
+
+	path, err := os.MkdirTemp("", "solc-switch-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create working directory: %w", err)
+	}
+
+	if s.workDirs == nil {
+		s.workDirs = make(map[string]*projectDir)
+	}
+	dir := &projectDir{path: path}
+	s.workDirs[project] = dir
+
+	return dir, nil
+}
+
+// lockWorkDir returns the locked working directory of the project. A directory removed by Cleanup while waiting
+// for the lock is replaced by a new one.
+func (s *Solc) lockWorkDir(project string) (*projectDir, error) {
+	for {
+		dir, err := s.workDir(project)
+		if err != nil {
+			return nil, err
+		}
+
+		dir.mu.Lock()
+		if !dir.removed {
+			return dir, nil
+		}
+		dir.mu.Unlock()
+	}
+}
+
+// syncWorkDir writes the changed sources into the working directory and removes the sources no longer present.
+// It returns the sources keyed by their cleaned path relative to the working directory.
+func syncWorkDir(dir string, sources map[string]string) (map[string]Source, error) {
+	expected := make(map[string]bool, len(sources))
+	known := make(map[string]Source, len(sources))
+
+	for name, content := range sources {
+		cleaned := filepath.Clean(filepath.FromSlash(name))
+		if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("source path must be relative to the project root: %s", name)
+		}
+
+		file := filepath.Join(dir, cleaned)
+		expected[file] = true
+		known[filepath.ToSlash(cleaned)] = Source{Content: content}
+
+		if existing, err := os.ReadFile(file); err == nil && bytes.Equal(existing, []byte(content)) {
+			continue
+		}
+
+		// #nosec G301
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return nil, err
+		}
+
+		if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+			return nil, err
+		}
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || expected[path] {
+			return nil
+		}
+
+		return os.Remove(path)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return known, nil
+}
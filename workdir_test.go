@@ -0,0 +1,113 @@
+package solc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileSources(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	installFakeBinary(t, s, "0.8.20", `cat > /dev/null; echo '{"contracts": {}}'`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	sources := map[string]string{
+		"contracts/Token.sol":      `import "./Math.sol"; contract Token {}`,
+		"contracts/Math.sol":       `library Math {}`,
+		"contracts/Deprecated.sol": `contract Deprecated {}`,
+	}
+
+	_, err = s.CompileSources(context.TODO(), "project", sources, config)
+	assert.NoError(t, err)
+
+	dir, err := s.workDir("project")
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir.path, "contracts", "Token.sol"))
+	assert.NoError(t, err)
+	assert.Equal(t, sources["contracts/Token.sol"], string(content))
+
+	// Mark the unchanged source so that we can tell whether it was rewritten.
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	assert.NoError(t, os.Chtimes(filepath.Join(dir.path, "contracts", "Math.sol"), past, past))
+
+	delete(sources, "contracts/Deprecated.sol")
+	sources["contracts/Token.sol"] = `import "./Math.sol"; contract Token { uint a; }`
+
+	_, err = s.CompileSources(context.TODO(), "project", sources, config)
+	assert.NoError(t, err)
+
+	info, err := os.Stat(filepath.Join(dir.path, "contracts", "Math.sol"))
+	assert.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(past), "unchanged sources must not be rewritten")
+
+	content, err = os.ReadFile(filepath.Join(dir.path, "contracts", "Token.sol"))
+	assert.NoError(t, err)
+	assert.Equal(t, sources["contracts/Token.sol"], string(content))
+
+	_, err = os.Stat(filepath.Join(dir.path, "contracts", "Deprecated.sol"))
+	assert.True(t, os.IsNotExist(err), "removed sources must be deleted")
+
+	assert.NoError(t, s.Cleanup())
+	_, err = os.Stat(dir.path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCompileSourcesConcurrent(t *testing.T) {
+	s := newTestSolc(t, Version{TagName: "v0.8.20"})
+	defer s.Cleanup()
+
+	// The binary fails unless the source it's given is still the one in the working directory once it's done.
+	installFakeBinary(t, s, "0.8.20", `
+while [ $# -gt 0 ]; do
+	if [ "$1" = "--base-path" ]; then base="$2"; fi
+	shift
+done
+input=$(cat)
+sleep 0.05
+content=$(cat "$base/A.sol")
+case "$input" in
+	*"$content"*) echo '{"contracts": {}}' ;;
+	*) echo "source rewritten while compiling" >&2; exit 1 ;;
+esac`)
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sources := map[string]string{"A.sol": fmt.Sprintf("contract A%d {}", i)}
+			_, err := s.CompileSources(context.TODO(), "project", sources, config)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestCompileSourcesErrors(t *testing.T) {
+	s := newTestSolc(t)
+	defer s.Cleanup()
+
+	config, err := NewDefaultCompilerConfig("0.8.20")
+	assert.NoError(t, err)
+
+	_, err = s.CompileSources(context.TODO(), "", map[string]string{"A.sol": "contract A {}"}, config)
+	assert.Error(t, err)
+
+	_, err = s.CompileSources(context.TODO(), "project", nil, config)
+	assert.Error(t, err)
+
+	_, err = s.CompileSources(context.TODO(), "project", map[string]string{"../A.sol": "contract A {}"}, config)
+	assert.ErrorContains(t, err, "relative to the project root")
+}